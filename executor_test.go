@@ -1635,6 +1635,84 @@ func TestQuery_InputObjectUsesFieldDefaultValueFn(t *testing.T) {
 	}
 }
 
+func TestQuery_ExplicitNullArgumentsOverrideDefaults(t *testing.T) {
+	inputType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "Input",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"x": &graphql.InputObjectFieldConfig{
+				Type:         graphql.String,
+				DefaultValue: "y",
+			},
+		},
+	})
+	q := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"f": &graphql.Field{
+				Type: graphql.String,
+				Args: graphql.FieldConfigArgument{
+					"nullable": &graphql.ArgumentConfig{
+						Type: graphql.Int,
+					},
+					"nonNull": &graphql.ArgumentConfig{
+						Type: graphql.NewNonNull(graphql.Int),
+					},
+					"defaulted": &graphql.ArgumentConfig{
+						Type:         graphql.Int,
+						DefaultValue: 5,
+					},
+					"input": &graphql.ArgumentConfig{
+						Type: inputType,
+					},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return fmt.Sprintf("%v", p.Args), nil
+				},
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: q,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+	tests := map[string]string{
+		`{ f(nonNull: 1) }`:                                 "map[defaulted:5 nonNull:1]",
+		`{ f(nullable: null, nonNull: 1) }`:                 "map[defaulted:5 nonNull:1 nullable:<nil>]",
+		`{ f(nonNull: 1, defaulted: null) }`:                "map[defaulted:<nil> nonNull:1]",
+		`{ f(nonNull: 1, defaulted: 2, input: {}) }`:        "map[defaulted:2 input:map[x:y] nonNull:1]",
+		`{ f(nonNull: 1, defaulted: 2, input: {x: null}) }`: "map[defaulted:2 input:map[x:<nil>] nonNull:1]",
+		`{ f(nonNull: 1, defaulted: 2, input: {x: "z"}) }`:  "map[defaulted:2 input:map[x:z] nonNull:1]",
+	}
+	for query, expected := range tests {
+		result := graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: query,
+		})
+		if len(result.Errors) != 0 {
+			t.Fatalf("wrong result for %v, unexpected errors: %+v", query, result.Errors)
+		}
+		if got := result.Data.(map[string]interface{})["f"]; got != expected {
+			t.Fatalf("wrong arguments for %v, expected %v, got %v", query, expected, got)
+		}
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ f(nonNull: null) }`,
+	})
+	expectedErrors := []gqlerrors.FormattedError{
+		{
+			Message:   "Argument \"nonNull\" has invalid value null.\nExpected \"Int!\", found null.",
+			Locations: []location.SourceLocation{{Line: 1, Column: 14}},
+		},
+	}
+	if !testutil.EqualFormattedErrors(expectedErrors, result.Errors) {
+		t.Fatalf("unexpected errors, Diff: %v", testutil.Diff(expectedErrors, result.Errors))
+	}
+}
+
 func TestMutation_ExecutionAddsErrorsFromFieldResolveFn(t *testing.T) {
 	mError := errors.New("mutationError")
 	q := graphql.NewObject(graphql.ObjectConfig{
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/graphql-go/graphql/language/ast"
 	"github.com/graphql-go/graphql/language/location"
@@ -24,8 +25,8 @@ func NewSyntaxError(s *source.Source, position int, description string) *Error {
 
 // printCharCode here is slightly different from lexer.printCharCode()
 func printCharCode(code rune) string {
-	// print as ASCII for printable range
-	if code >= 0x0020 {
+	// print as ASCII for printable range, keeping tabs so carets can align
+	if code >= 0x0020 || code == '\t' {
 		return fmt.Sprintf(`%c`, code)
 	}
 	// Otherwise print the escaped form. e.g. `"\\u0007"`
@@ -38,21 +39,67 @@ func printLine(str string) string {
 	}
 	return fmt.Sprintf(`%s`, strings.Join(strSlice, ""))
 }
+
+// SourceSnippet returns the source line holding position followed by a line
+// with a caret under its column, e.g. to print beside an error message. Tabs
+// before the column are repeated in the caret line so the caret lines up
+// however they are displayed, and positions past the end of a line or of the
+// source point just after its last character.
+func SourceSnippet(s *source.Source, position int) string {
+	l := location.GetLocation(s, position)
+	lines := sourceLines(s)
+	line := lines[clampLine(l.Line, len(lines))-1]
+	return fmt.Sprintf("%s\n%s^", printLine(line), caretPadding(line, l.Column))
+}
+
+func sourceLines(s *source.Source) []string {
+	if s == nil {
+		return []string{""}
+	}
+	return regexp.MustCompile("\r\n|[\n\r]").Split(string(s.Body), -1)
+}
+
+func clampLine(line, count int) int {
+	if line < 1 {
+		return 1
+	}
+	if line > count {
+		return count
+	}
+	return line
+}
+
+// caretPadding returns the whitespace preceding a caret under the given column
+// of line once printed with printLine.
+func caretPadding(line string, column int) string {
+	var padding string
+	runes := []rune(line)
+	if column > len(runes)+1 {
+		column = len(runes) + 1
+	}
+	for i := 0; i < column-1; i++ {
+		if runes[i] == '\t' {
+			padding += "\t"
+			continue
+		}
+		padding += strings.Repeat(" ", utf8.RuneCountInString(printCharCode(runes[i])))
+	}
+	return padding
+}
+
 func highlightSourceAtLocation(s *source.Source, l location.SourceLocation) string {
-	line := l.Line
+	lines := sourceLines(s)
+	line := clampLine(l.Line, len(lines))
 	prevLineNum := fmt.Sprintf("%d", (line - 1))
 	lineNum := fmt.Sprintf("%d", line)
 	nextLineNum := fmt.Sprintf("%d", (line + 1))
 	padLen := len(nextLineNum)
-	lines := regexp.MustCompile("\r\n|[\n\r]").Split(string(s.Body), -1)
 	var highlight string
 	if line >= 2 {
 		highlight += fmt.Sprintf("%s: %s\n", lpad(padLen, prevLineNum), printLine(lines[line-2]))
 	}
 	highlight += fmt.Sprintf("%s: %s\n", lpad(padLen, lineNum), printLine(lines[line-1]))
-	for i := 1; i < (2 + padLen + l.Column); i++ {
-		highlight += " "
-	}
+	highlight += strings.Repeat(" ", padLen+2) + caretPadding(lines[line-1], l.Column)
 	highlight += "^\n"
 	if line < len(lines) {
 		highlight += fmt.Sprintf("%s: %s\n", lpad(padLen, nextLineNum), printLine(lines[line]))
@@ -0,0 +1,41 @@
+package gqlerrors_test
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+func TestSourceSnippet(t *testing.T) {
+	tests := []struct {
+		body     string
+		position int
+		expected string
+	}{
+		{"{ field(: 1) }", 8, "{ field(: 1) }\n        ^"},
+		{"{\n  field(: 1)\n}", 10, "  field(: 1)\n        ^"},
+		{"{\r\n\tfield(: 1)\r\n}", 10, "\tfield(: 1)\n\t      ^"},
+		{"{ field", 7, "{ field\n       ^"},
+		{"{ field\n", 8, "\n^"},
+		{"{ field }", 42, "{ field }\n         ^"},
+	}
+	for _, test := range tests {
+		s := source.NewSource(&source.Source{Body: []byte(test.body)})
+		if snippet := gqlerrors.SourceSnippet(s, test.position); snippet != test.expected {
+			t.Fatalf("unexpected snippet of %d in %q, expected:\n%v\ngot:\n%v", test.position, test.body, test.expected, snippet)
+		}
+	}
+}
+
+func TestNewSyntaxError_AlignsCaretAfterTabs(t *testing.T) {
+	s := source.NewSource(&source.Source{Body: []byte("{\n\t\tfield(: 1)\n}")})
+	expected := "Syntax Error GraphQL (2:9) Expected Name, found :\n\n" +
+		"1: {\n" +
+		"2: \t\tfield(: 1)\n" +
+		"   \t\t      ^\n" +
+		"3: }\n"
+	if err := gqlerrors.NewSyntaxError(s, 10, "Expected Name, found :"); err.Message != expected {
+		t.Fatalf("unexpected message, expected:\n%q\ngot:\n%q", expected, err.Message)
+	}
+}
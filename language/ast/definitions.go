@@ -17,6 +17,19 @@ var _ Definition = (*OperationDefinition)(nil)
 var _ Definition = (*FragmentDefinition)(nil)
 var _ Definition = (TypeSystemDefinition)(nil) // experimental non-spec addition.
 
+// SelectionSetOf returns the selection set of an executable definition.
+// Operations and fragments report true; type system definitions, which carry
+// no selection set, report false.
+func SelectionSetOf(def Definition) (*SelectionSet, bool) {
+	switch def := def.(type) {
+	case *OperationDefinition:
+		return def.SelectionSet, true
+	case *FragmentDefinition:
+		return def.SelectionSet, true
+	}
+	return nil, false
+}
+
 // Note: subscription is an experimental non-spec addition.
 const (
 	OperationTypeQuery        = "query"
@@ -123,6 +136,35 @@ func (fd *FragmentDefinition) GetSelectionSet() *SelectionSet {
 	return fd.SelectionSet
 }
 
+// SpreadNames returns the distinct names of the fragments spread within the
+// fragment, at any depth and in document order. Spreads are not followed into
+// the fragments they name, so the result is the fragment's direct dependencies.
+func (fd *FragmentDefinition) SpreadNames() []string {
+	names := []string{}
+	seen := map[string]bool{}
+	var collect func(selectionSet *SelectionSet)
+	collect = func(selectionSet *SelectionSet) {
+		if selectionSet == nil {
+			return
+		}
+		for _, selection := range selectionSet.Selections {
+			switch selection := selection.(type) {
+			case *Field:
+				collect(selection.SelectionSet)
+			case *InlineFragment:
+				collect(selection.SelectionSet)
+			case *FragmentSpread:
+				if selection.Name != nil && !seen[selection.Name.Value] {
+					seen[selection.Name.Value] = true
+					names = append(names, selection.Name.Value)
+				}
+			}
+		}
+	}
+	collect(fd.SelectionSet)
+	return names
+}
+
 // VariableDefinition implements Node
 type VariableDefinition struct {
 	Kind         string
@@ -186,6 +228,44 @@ func (def *TypeExtensionDefinition) GetOperation() string {
 	return ""
 }
 
+// InterfaceExtensionDefinition implements Node, Definition
+type InterfaceExtensionDefinition struct {
+	Kind       string
+	Loc        *Location
+	Definition *InterfaceDefinition
+}
+
+func NewInterfaceExtensionDefinition(def *InterfaceExtensionDefinition) *InterfaceExtensionDefinition {
+	if def == nil {
+		def = &InterfaceExtensionDefinition{}
+	}
+	return &InterfaceExtensionDefinition{
+		Kind:       kinds.InterfaceExtensionDefinition,
+		Loc:        def.Loc,
+		Definition: def.Definition,
+	}
+}
+
+func (def *InterfaceExtensionDefinition) GetKind() string {
+	return def.Kind
+}
+
+func (def *InterfaceExtensionDefinition) GetLoc() *Location {
+	return def.Loc
+}
+
+func (def *InterfaceExtensionDefinition) GetVariableDefinitions() []*VariableDefinition {
+	return []*VariableDefinition{}
+}
+
+func (def *InterfaceExtensionDefinition) GetSelectionSet() *SelectionSet {
+	return &SelectionSet{}
+}
+
+func (def *InterfaceExtensionDefinition) GetOperation() string {
+	return ""
+}
+
 // DirectiveDefinition implements Node, Definition
 type DirectiveDefinition struct {
 	Kind        string
@@ -193,6 +273,7 @@ type DirectiveDefinition struct {
 	Name        *Name
 	Description *StringValue
 	Arguments   []*InputValueDefinition
+	Repeatable  bool
 	Locations   []*Name
 }
 
@@ -206,6 +287,7 @@ func NewDirectiveDefinition(def *DirectiveDefinition) *DirectiveDefinition {
 		Name:        def.Name,
 		Description: def.Description,
 		Arguments:   def.Arguments,
+		Repeatable:  def.Repeatable,
 		Locations:   def.Locations,
 	}
 }
@@ -0,0 +1,115 @@
+package ast_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/kinds"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+func parse(t *testing.T, query string) *ast.Document {
+	astDoc, err := parser.Parse(parser.ParseParams{
+		Source: query,
+		Options: parser.ParseOptions{
+			NoLocation: true,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	return astDoc
+}
+
+func TestSelectionSetOf(t *testing.T) {
+	doc := parse(t, `
+query Q { a }
+fragment F on T { b c }
+type T { b: String c: String }
+`)
+	tests := []struct {
+		selections int
+		ok         bool
+	}{
+		{1, true},
+		{2, true},
+		{0, false},
+	}
+	for i, test := range tests {
+		def := doc.Definitions[i].(ast.Definition)
+		selectionSet, ok := ast.SelectionSetOf(def)
+		if ok != test.ok {
+			t.Fatalf("definition %d: expected ok %v, got %v", i, test.ok, ok)
+		}
+		if !ok {
+			if selectionSet != nil {
+				t.Fatalf("definition %d: expected nil selection set, got %v", i, selectionSet)
+			}
+			continue
+		}
+		if len(selectionSet.Selections) != test.selections {
+			t.Fatalf("definition %d: expected %d selections, got %d", i, test.selections, len(selectionSet.Selections))
+		}
+	}
+}
+
+func TestFragmentDefinition_SpreadNames(t *testing.T) {
+	doc := parse(t, `
+fragment UserFields on User {
+  id
+  ...Avatar
+  friends {
+    ...Avatar
+    ... on Admin { ...Permissions }
+  }
+}
+fragment Avatar on User { avatar }
+fragment Permissions on Admin { permissions }
+`)
+	fragment := doc.Definitions[0].(*ast.FragmentDefinition)
+	if names := fragment.SpreadNames(); !reflect.DeepEqual(names, []string{"Avatar", "Permissions"}) {
+		t.Fatalf("unexpected spread names: %v", names)
+	}
+	fragment = doc.Definitions[1].(*ast.FragmentDefinition)
+	if names := fragment.SpreadNames(); len(names) != 0 {
+		t.Fatalf("expected no spread names, got: %v", names)
+	}
+}
+
+func TestDefinitionGetters(t *testing.T) {
+	doc := parse(t, `
+mutation M($id: ID!) { like(id: $id) }
+fragment F on T { b c }
+type T { b: String c: String }
+`)
+	tests := []struct {
+		kind       string
+		operation  string
+		variables  int
+		selections int
+	}{
+		{kinds.OperationDefinition, ast.OperationTypeMutation, 1, 1},
+		{kinds.FragmentDefinition, "", 0, 2},
+		{kinds.ObjectDefinition, "", 0, 0},
+	}
+	for i, def := range doc.Definitions {
+		def := def.(ast.Definition)
+		test := tests[i]
+		if def.GetKind() != test.kind {
+			t.Fatalf("definition %d: expected kind %v, got %v", i, test.kind, def.GetKind())
+		}
+		if def.GetOperation() != test.operation {
+			t.Fatalf("definition %d: expected operation %q, got %q", i, test.operation, def.GetOperation())
+		}
+		if len(def.GetVariableDefinitions()) != test.variables {
+			t.Fatalf("definition %d: expected %d variables, got %v", i, test.variables, def.GetVariableDefinitions())
+		}
+		if def.GetSelectionSet() == nil || len(def.GetSelectionSet().Selections) != test.selections {
+			t.Fatalf("definition %d: expected %d selections, got %v", i, test.selections, def.GetSelectionSet())
+		}
+		if def.GetLoc() != nil {
+			t.Fatalf("definition %d: expected no location when parsed with NoLocation, got %v", i, def.GetLoc())
+		}
+	}
+}
@@ -23,6 +23,7 @@ var _ Node = (*FloatValue)(nil)
 var _ Node = (*StringValue)(nil)
 var _ Node = (*BooleanValue)(nil)
 var _ Node = (*EnumValue)(nil)
+var _ Node = (*NullValue)(nil)
 var _ Node = (*ListValue)(nil)
 var _ Node = (*ObjectValue)(nil)
 var _ Node = (*ObjectField)(nil)
@@ -42,4 +43,5 @@ var _ Node = (*EnumDefinition)(nil)
 var _ Node = (*EnumValueDefinition)(nil)
 var _ Node = (*InputObjectDefinition)(nil)
 var _ Node = (*TypeExtensionDefinition)(nil)
+var _ Node = (*InterfaceExtensionDefinition)(nil)
 var _ Node = (*DirectiveDefinition)(nil)
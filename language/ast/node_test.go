@@ -0,0 +1,53 @@
+package ast_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/kinds"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+func TestNode_KindsAndLocations(t *testing.T) {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source:  `{ user(id: 4) @include(if: true) { name } }`,
+		Options: parser.ParseOptions{NoSource: true},
+	})
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	field := doc.Definitions[0].(*ast.OperationDefinition).SelectionSet.Selections[0].(*ast.Field)
+	directive := field.Directives[0]
+	nodes := []ast.Node{
+		doc,
+		field,
+		field.Name,
+		field.Arguments[0],
+		field.Arguments[0].Value,
+		directive,
+		directive.Arguments[0].Value,
+		field.SelectionSet,
+	}
+	expected := []struct {
+		kind       string
+		start, end int
+	}{
+		{kinds.Document, 0, 43},
+		{kinds.Field, 2, 41},
+		{kinds.Name, 2, 6},
+		{kinds.Argument, 7, 12},
+		{kinds.IntValue, 11, 12},
+		{kinds.Directive, 14, 32},
+		{kinds.BooleanValue, 27, 31},
+		{kinds.SelectionSet, 33, 41},
+	}
+	for i, node := range nodes {
+		if node.GetKind() != expected[i].kind {
+			t.Fatalf("node %d: expected kind %v, got %v", i, expected[i].kind, node.GetKind())
+		}
+		if loc := (&ast.Location{Start: expected[i].start, End: expected[i].end}); !reflect.DeepEqual(node.GetLoc(), loc) {
+			t.Fatalf("node %d: expected location %v, got %v", i, loc, node.GetLoc())
+		}
+	}
+}
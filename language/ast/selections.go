@@ -1,6 +1,8 @@
 package ast
 
 import (
+	"sort"
+
 	"github.com/graphql-go/graphql/language/kinds"
 )
 
@@ -44,6 +46,40 @@ func (f *Field) GetSelectionSet() *SelectionSet {
 	return f.SelectionSet
 }
 
+// ArgumentsInOrder returns the field arguments in declaration order.
+func (f *Field) ArgumentsInOrder() []*Argument {
+	return f.Arguments
+}
+
+// ArgumentsSorted returns a copy of the field arguments sorted by name.
+func (f *Field) ArgumentsSorted() []*Argument {
+	args := make([]*Argument, len(f.Arguments))
+	copy(args, f.Arguments)
+	sort.SliceStable(args, func(i, j int) bool {
+		return argumentName(args[i]) < argumentName(args[j])
+	})
+	return args
+}
+
+// ArgumentLocation returns the source location of the named argument, e.g. to
+// point diagnostics at it. It reports false when the field has no such
+// argument or the argument carries no location.
+func (f *Field) ArgumentLocation(name string) (*Location, bool) {
+	for _, arg := range f.Arguments {
+		if argumentName(arg) == name && arg.Loc != nil {
+			return arg.Loc, true
+		}
+	}
+	return nil, false
+}
+
+func argumentName(arg *Argument) string {
+	if arg == nil || arg.Name == nil {
+		return ""
+	}
+	return arg.Name.Value
+}
+
 // FragmentSpread implements Node, Selection
 type FragmentSpread struct {
 	Kind       string
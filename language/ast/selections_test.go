@@ -0,0 +1,63 @@
+package ast_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+func argumentNames(args []*ast.Argument) []string {
+	names := []string{}
+	for _, arg := range args {
+		names = append(names, arg.Name.Value)
+	}
+	return names
+}
+
+func TestField_ArgumentsInOrderAndSorted(t *testing.T) {
+	doc := parse(t, `{ users(limit: 10, after: "x", orderBy: NAME) }`)
+	op := doc.Definitions[0].(*ast.OperationDefinition)
+	field := op.SelectionSet.Selections[0].(*ast.Field)
+
+	expectedInOrder := []string{"limit", "after", "orderBy"}
+	if names := argumentNames(field.ArgumentsInOrder()); !reflect.DeepEqual(names, expectedInOrder) {
+		t.Fatalf("unexpected argument order, expected: %v, got: %v", expectedInOrder, names)
+	}
+	expectedSorted := []string{"after", "limit", "orderBy"}
+	if names := argumentNames(field.ArgumentsSorted()); !reflect.DeepEqual(names, expectedSorted) {
+		t.Fatalf("unexpected sorted arguments, expected: %v, got: %v", expectedSorted, names)
+	}
+	// sorting must not reorder the field's own arguments
+	if names := argumentNames(field.Arguments); !reflect.DeepEqual(names, expectedInOrder) {
+		t.Fatalf("field arguments were modified, expected: %v, got: %v", expectedInOrder, names)
+	}
+}
+
+func TestField_ArgumentLocation(t *testing.T) {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source:  `{ users(limit: 10, after: "x") }`,
+		Options: parser.ParseOptions{NoSource: true},
+	})
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	field := doc.Definitions[0].(*ast.OperationDefinition).SelectionSet.Selections[0].(*ast.Field)
+	tests := []struct {
+		name     string
+		expected *ast.Location
+	}{
+		{"limit", &ast.Location{Start: 8, End: 17}},
+		{"after", &ast.Location{Start: 19, End: 29}},
+	}
+	for _, test := range tests {
+		loc, ok := field.ArgumentLocation(test.name)
+		if !ok || !reflect.DeepEqual(loc, test.expected) {
+			t.Fatalf("unexpected location for %v, expected: %v, got: %v", test.name, test.expected, loc)
+		}
+	}
+	if loc, ok := field.ArgumentLocation("first"); ok {
+		t.Fatalf("expected no location for a missing argument, got: %v", loc)
+	}
+}
@@ -15,6 +15,32 @@ var _ Type = (*Named)(nil)
 var _ Type = (*List)(nil)
 var _ Type = (*NonNull)(nil)
 
+// IsBuiltInScalar reports whether name refers to one of the scalars defined
+// by the GraphQL specification: ID, Int, Float, String or Boolean.
+func IsBuiltInScalar(name string) bool {
+	switch name {
+	case "ID", "Int", "Float", "String", "Boolean":
+		return true
+	}
+	return false
+}
+
+// TypeString renders a type reference as it is written in GraphQL, e.g. `[Int!]!`.
+func TypeString(t Type) string {
+	switch t := t.(type) {
+	case *Named:
+		if t.Name == nil {
+			return ""
+		}
+		return t.Name.Value
+	case *List:
+		return "[" + TypeString(t.Type) + "]"
+	case *NonNull:
+		return TypeString(t.Type) + "!"
+	}
+	return ""
+}
+
 // Named implements Node, Type
 type Named struct {
 	Kind string
@@ -0,0 +1,44 @@
+package ast_test
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+func TestTypeString(t *testing.T) {
+	tests := []string{
+		"Int",
+		"Int!",
+		"[Int]",
+		"[Int!]",
+		"[Int]!",
+		"[Int!]!",
+		"[[String!]]!",
+	}
+	for _, expected := range tests {
+		doc := parse(t, "query Q($x: "+expected+") { a }")
+		op := doc.Definitions[0].(*ast.OperationDefinition)
+		if got := ast.TypeString(op.VariableDefinitions[0].Type); got != expected {
+			t.Fatalf("unexpected type string, expected: %v, got: %v", expected, got)
+		}
+	}
+	if got := ast.TypeString(nil); got != "" {
+		t.Fatalf("expected empty string for nil type, got: %v", got)
+	}
+}
+
+func TestIsBuiltInScalar(t *testing.T) {
+	doc := parse(t, "query Q($a: ID, $b: Int, $c: Float, $d: String, $e: Boolean, $f: DateTime) { a }")
+	op := doc.Definitions[0].(*ast.OperationDefinition)
+	for i, vd := range op.VariableDefinitions {
+		named, ok := vd.Type.(*ast.Named)
+		if !ok {
+			t.Fatalf("expected a named type, got: %T", vd.Type)
+		}
+		expected := i < 5
+		if got := ast.IsBuiltInScalar(named.Name.Value); got != expected {
+			t.Fatalf("IsBuiltInScalar(%v): expected %v, got %v", named.Name.Value, expected, got)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package astutil
+
+import (
+	"reflect"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+var sourceType = reflect.TypeOf(source.Source{})
+
+// deepCopy returns a copy of an AST sharing no nodes with the original, so a
+// transform can rewrite the copy in place. Sources are shared rather than
+// copied since nodes only ever point at them.
+func deepCopy(node interface{}) interface{} {
+	if node == nil {
+		return nil
+	}
+	return copyValue(reflect.ValueOf(node)).Interface()
+}
+
+func copyValue(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() || v.Elem().Type() == sourceType {
+			return v
+		}
+		c := reflect.New(v.Elem().Type())
+		c.Elem().Set(copyValue(v.Elem()))
+		return c
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(copyValue(v.Elem()))
+		return c
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.NumField(); i++ {
+			if c.Field(i).CanSet() {
+				c.Field(i).Set(copyValue(v.Field(i)))
+			}
+		}
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(copyValue(v.Index(i)))
+		}
+		return c
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		for _, key := range v.MapKeys() {
+			c.SetMapIndex(key, copyValue(v.MapIndex(key)))
+		}
+		return c
+	}
+	return v
+}
+
+var locationType = reflect.TypeOf(&ast.Location{})
+
+// clearLocations sets every Loc field reachable from node to nil, in place.
+func clearLocations(node interface{}) {
+	if node == nil {
+		return
+	}
+	clearLocationsValue(reflect.ValueOf(node))
+}
+
+func clearLocationsValue(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			clearLocationsValue(v.Elem())
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Field(i)
+			if field.Type() == locationType {
+				if field.CanSet() {
+					field.Set(reflect.Zero(locationType))
+				}
+				continue
+			}
+			clearLocationsValue(field)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			clearLocationsValue(v.Index(i))
+		}
+	}
+}
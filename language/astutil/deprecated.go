@@ -0,0 +1,43 @@
+package astutil
+
+import (
+	"fmt"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// DeprecatedFieldsUsed reports every field selected in query whose definition
+// in the schema document carries `@deprecated`. Root types come from the
+// schema definition, defaulting to Query, Mutation and Subscription. Fields
+// of types unknown to the schema are skipped rather than reported.
+func DeprecatedFieldsUsed(schema, query *ast.Document) []error {
+	errs := []error{}
+	walkSchemaFields(schema, query, func(typeName string, field *ast.Field, def *ast.FieldDefinition) {
+		if reason, ok := deprecationReason(def.Directives); ok {
+			errs = append(errs, newError(
+				fmt.Sprintf(`The field %v.%v is deprecated. %v`, typeName, field.Name.Value, reason),
+				[]ast.Node{field},
+			))
+		}
+	})
+	return errs
+}
+
+// deprecationReason returns the reason given to a `@deprecated` directive, or
+// the default reason when the directive has no reason argument.
+func deprecationReason(directives []*ast.Directive) (string, bool) {
+	for _, directive := range directives {
+		if directive.Name == nil || directive.Name.Value != "deprecated" {
+			continue
+		}
+		for _, arg := range directive.Arguments {
+			if arg.Name != nil && arg.Name.Value == "reason" {
+				if reason, ok := arg.Value.(*ast.StringValue); ok {
+					return reason.Value, true
+				}
+			}
+		}
+		return "No longer supported", true
+	}
+	return "", false
+}
@@ -0,0 +1,56 @@
+package astutil_test
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql/language/astutil"
+)
+
+func TestDeprecatedFieldsUsed(t *testing.T) {
+	schema := parse(t, `
+type Query {
+  user(id: ID!): User
+  me: User @deprecated(reason: "Use user instead.")
+}
+interface Node {
+  id: ID!
+}
+type User implements Node {
+  id: ID!
+  name: String
+  username: String @deprecated
+}
+`)
+	query := parse(t, `
+query Q {
+  me { name }
+  user(id: 1) {
+    username
+    ... on Node { id }
+    ...UserFields
+  }
+}
+fragment UserFields on User {
+  name
+  username
+}
+`)
+	expectErrors(t, astutil.DeprecatedFieldsUsed(schema, query),
+		`The field Query.me is deprecated. Use user instead.`,
+		`The field User.username is deprecated. No longer supported`,
+		`The field User.username is deprecated. No longer supported`,
+	)
+}
+
+func TestDeprecatedFieldsUsed_HonorsSchemaRootTypes(t *testing.T) {
+	schema := parse(t, `
+schema { query: Root }
+type Root {
+  legacy: String @deprecated(reason: "Gone.")
+}
+`)
+	query := parse(t, `{ legacy }`)
+	expectErrors(t, astutil.DeprecatedFieldsUsed(schema, query),
+		`The field Root.legacy is deprecated. Gone.`,
+	)
+}
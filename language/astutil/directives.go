@@ -0,0 +1,165 @@
+package astutil
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/kinds"
+	"github.com/graphql-go/graphql/language/visitor"
+)
+
+// CheckDirectiveArguments validates directive usages against the directive
+// definitions declared in the same document. Each usage may only supply
+// declared arguments and must provide every non-null argument without a
+// default. Usages of directives not defined in doc are ignored.
+func CheckDirectiveArguments(doc *ast.Document) []error {
+	errs := []error{}
+	if doc == nil {
+		return errs
+	}
+	definitions := map[string]*ast.DirectiveDefinition{}
+	for _, def := range doc.Definitions {
+		if def, ok := def.(*ast.DirectiveDefinition); ok && def.Name != nil {
+			definitions[def.Name.Value] = def
+		}
+	}
+	if len(definitions) == 0 {
+		return errs
+	}
+	visitor.Visit(doc, &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.Directive: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					directive, ok := p.Node.(*ast.Directive)
+					if !ok || directive.Name == nil {
+						return visitor.ActionNoChange, nil
+					}
+					def, ok := definitions[directive.Name.Value]
+					if !ok {
+						return visitor.ActionNoChange, nil
+					}
+					argDefs := map[string]*ast.InputValueDefinition{}
+					for _, argDef := range def.Arguments {
+						if argDef.Name != nil {
+							argDefs[argDef.Name.Value] = argDef
+						}
+					}
+					provided := map[string]bool{}
+					for _, arg := range directive.Arguments {
+						if arg.Name == nil {
+							continue
+						}
+						provided[arg.Name.Value] = true
+						if _, ok := argDefs[arg.Name.Value]; !ok {
+							errs = append(errs, newError(
+								fmt.Sprintf(`Unknown argument "%v" on directive "@%v".`, arg.Name.Value, directive.Name.Value),
+								[]ast.Node{arg},
+							))
+						}
+					}
+					for _, argDef := range def.Arguments {
+						if argDef.Name == nil || provided[argDef.Name.Value] || argDef.DefaultValue != nil {
+							continue
+						}
+						if _, ok := argDef.Type.(*ast.NonNull); ok {
+							errs = append(errs, newError(
+								fmt.Sprintf(`Directive "@%v" argument "%v" of type "%v" is required but not provided.`,
+									directive.Name.Value, argDef.Name.Value, ast.TypeString(argDef.Type)),
+								[]ast.Node{directive},
+							))
+						}
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+		},
+	}, nil)
+	return errs
+}
+
+// directiveLocations maps the kind of node a directive is applied to onto its
+// directive location. Operations and input values are resolved separately.
+var directiveLocations = map[string]string{
+	kinds.Field:                 "FIELD",
+	kinds.FragmentSpread:        "FRAGMENT_SPREAD",
+	kinds.InlineFragment:        "INLINE_FRAGMENT",
+	kinds.FragmentDefinition:    "FRAGMENT_DEFINITION",
+	kinds.SchemaDefinition:      "SCHEMA",
+	kinds.ScalarDefinition:      "SCALAR",
+	kinds.ObjectDefinition:      "OBJECT",
+	kinds.FieldDefinition:       "FIELD_DEFINITION",
+	kinds.InterfaceDefinition:   "INTERFACE",
+	kinds.UnionDefinition:       "UNION",
+	kinds.EnumDefinition:        "ENUM",
+	kinds.EnumValueDefinition:   "ENUM_VALUE",
+	kinds.InputObjectDefinition: "INPUT_OBJECT",
+}
+
+// DirectivesInValidLocations checks every directive usage against the `on`
+// locations of the directive definitions declared in the same document, e.g.
+// a FIELD-only directive applied to a query operation. Usages of directives
+// not defined in doc are ignored.
+func DirectivesInValidLocations(doc *ast.Document) []error {
+	errs := []error{}
+	if doc == nil {
+		return errs
+	}
+	allowed := map[string]map[string]bool{}
+	for _, def := range doc.Definitions {
+		if def, ok := def.(*ast.DirectiveDefinition); ok && def.Name != nil {
+			allowed[def.Name.Value] = map[string]bool{}
+			for _, location := range def.Locations {
+				allowed[def.Name.Value][location.Value] = true
+			}
+		}
+	}
+	if len(allowed) == 0 {
+		return errs
+	}
+	visitor.Visit(doc, &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.Directive: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					directive, ok := p.Node.(*ast.Directive)
+					if !ok || directive.Name == nil {
+						return visitor.ActionNoChange, nil
+					}
+					locations, ok := allowed[directive.Name.Value]
+					if !ok {
+						return visitor.ActionNoChange, nil
+					}
+					location := directiveLocation(p.Ancestors)
+					if !locations[location] {
+						errs = append(errs, newError(
+							fmt.Sprintf(`Directive "%v" may not be used on %v.`, directive.Name.Value, location),
+							[]ast.Node{directive},
+						))
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+		},
+	}, nil)
+	return errs
+}
+
+// directiveLocation returns the location of a directive given its ancestors,
+// the last of which is the node it is applied to.
+func directiveLocation(ancestors []ast.Node) string {
+	if len(ancestors) == 0 || ancestors[len(ancestors)-1] == nil {
+		return ""
+	}
+	switch appliedTo := ancestors[len(ancestors)-1].(type) {
+	case *ast.OperationDefinition:
+		return strings.ToUpper(appliedTo.Operation)
+	case *ast.InputValueDefinition:
+		if len(ancestors) >= 3 && ancestors[len(ancestors)-3] != nil &&
+			ancestors[len(ancestors)-3].GetKind() == kinds.InputObjectDefinition {
+			return "INPUT_FIELD_DEFINITION"
+		}
+		return "ARGUMENT_DEFINITION"
+	default:
+		return directiveLocations[appliedTo.GetKind()]
+	}
+}
@@ -0,0 +1,65 @@
+package astutil_test
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql/language/astutil"
+)
+
+const directiveDefinitions = `
+directive @cached(ttl: Int!, scope: String, version: Int! = 1) on FIELD
+`
+
+func TestCheckDirectiveArguments_AcceptsValidUsage(t *testing.T) {
+	doc := parse(t, directiveDefinitions+`
+{ user @cached(ttl: 60, scope: "private") { name @skip(if: true) } }
+`)
+	expectErrors(t, astutil.CheckDirectiveArguments(doc))
+}
+
+func TestCheckDirectiveArguments_ReportsMissingRequiredArgument(t *testing.T) {
+	doc := parse(t, directiveDefinitions+`
+{ user @cached(scope: "private") { name } }
+`)
+	expectErrors(t, astutil.CheckDirectiveArguments(doc),
+		`Directive "@cached" argument "ttl" of type "Int!" is required but not provided.`,
+	)
+}
+
+func TestCheckDirectiveArguments_ReportsUnknownArgument(t *testing.T) {
+	doc := parse(t, directiveDefinitions+`
+{ user @cached(ttl: 60, maxAge: 10) { name } }
+`)
+	expectErrors(t, astutil.CheckDirectiveArguments(doc),
+		`Unknown argument "maxAge" on directive "@cached".`,
+	)
+}
+
+func TestDirectivesInValidLocations(t *testing.T) {
+	doc := parse(t, `
+directive @trace on FIELD
+directive @internal on FIELD_DEFINITION | ARGUMENT_DEFINITION
+
+type Query {
+  user(id: ID @internal): User @internal
+}
+input Filter {
+  name: String @internal
+}
+query Q @trace {
+  user @trace { name @include(if: true) }
+}
+`)
+	expectErrors(t, astutil.DirectivesInValidLocations(doc),
+		`Directive "internal" may not be used on INPUT_FIELD_DEFINITION.`,
+		`Directive "trace" may not be used on QUERY.`,
+	)
+}
+
+func TestDirectivesInValidLocations_AcceptsFieldUsage(t *testing.T) {
+	doc := parse(t, `
+directive @trace on FIELD
+{ user @trace { name @trace } }
+`)
+	expectErrors(t, astutil.DirectivesInValidLocations(doc))
+}
@@ -0,0 +1,138 @@
+package astutil
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/kinds"
+)
+
+// graphQLJSKinds maps the kinds whose names differ from the ones graphql-js
+// uses. Every other kind is named the same in both.
+var graphQLJSKinds = map[string]string{
+	kinds.Named:                        "NamedType",
+	kinds.List:                         "ListType",
+	kinds.NonNull:                      "NonNullType",
+	kinds.ScalarDefinition:             "ScalarTypeDefinition",
+	kinds.ObjectDefinition:             "ObjectTypeDefinition",
+	kinds.InterfaceDefinition:          "InterfaceTypeDefinition",
+	kinds.UnionDefinition:              "UnionTypeDefinition",
+	kinds.EnumDefinition:               "EnumTypeDefinition",
+	kinds.InputObjectDefinition:        "InputObjectTypeDefinition",
+	kinds.TypeExtensionDefinition:      "ObjectTypeExtension",
+	kinds.InterfaceExtensionDefinition: "InterfaceTypeExtension",
+}
+
+// ToGraphQLJSAST converts doc into the nested maps graphql-js `parse` returns,
+// as they appear once serialized to JSON: every node carries its `kind` and
+// `loc: {start, end}`, keys are camelCase, absent optional children are left
+// out and absent lists are empty. Type extensions are flattened onto the
+// extension node the way graphql-js represents them. Block strings cannot be
+// told apart from regular strings once parsed, so `block` is always false.
+func ToGraphQLJSAST(doc *ast.Document) (map[string]interface{}, error) {
+	if doc == nil {
+		return nil, fmt.Errorf("cannot convert a nil document")
+	}
+	return graphQLJSNode(doc)
+}
+
+func graphQLJSNode(node ast.Node) (map[string]interface{}, error) {
+	v := reflect.ValueOf(node)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("unsupported node %T", node)
+	}
+	kind := node.GetKind()
+	if kind == "" {
+		return nil, fmt.Errorf("unsupported node %T without a kind", node)
+	}
+	if jsKind, ok := graphQLJSKinds[kind]; ok {
+		kind = jsKind
+	}
+	result := map[string]interface{}{"kind": kind}
+	if loc := node.GetLoc(); loc != nil {
+		result["loc"] = map[string]interface{}{"start": loc.Start, "end": loc.End}
+	}
+
+	fields := v.Elem()
+	switch node := node.(type) {
+	case *ast.TypeExtensionDefinition:
+		if node.Definition == nil {
+			return nil, fmt.Errorf("type extension without a definition")
+		}
+		fields = reflect.ValueOf(node.Definition).Elem()
+	case *ast.InterfaceExtensionDefinition:
+		if node.Definition == nil {
+			return nil, fmt.Errorf("interface extension without a definition")
+		}
+		fields = reflect.ValueOf(node.Definition).Elem()
+	}
+	for i := 0; i < fields.NumField(); i++ {
+		name := fields.Type().Field(i).Name
+		if skipGraphQLJSField(kind, name) {
+			continue
+		}
+		value, ok, err := graphQLJSValue(fields.Field(i))
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			result[strings.ToLower(name[:1])+name[1:]] = value
+		}
+	}
+
+	switch kind {
+	case kinds.StringValue:
+		result["block"] = false
+	case kinds.VariableDefinition:
+		result["directives"] = []interface{}{}
+	}
+	return result, nil
+}
+
+// skipGraphQLJSField reports whether a struct field has no graphql-js
+// counterpart on nodes of the given (graphql-js) kind.
+func skipGraphQLJSField(kind, name string) bool {
+	switch name {
+	case "Kind", "Loc":
+		return true
+	case "Operation", "VariableDefinitions":
+		return kind == kinds.FragmentDefinition
+	case "Description":
+		return kind == "ObjectTypeExtension" || kind == "InterfaceTypeExtension"
+	}
+	return false
+}
+
+// graphQLJSValue converts a node field, reporting false when the field is an
+// absent optional child that graphql-js leaves undefined.
+func graphQLJSValue(v reflect.Value) (interface{}, bool, error) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil, false, nil
+		}
+		node, ok := v.Interface().(ast.Node)
+		if !ok {
+			return nil, false, fmt.Errorf("unsupported value %v", v.Type())
+		}
+		converted, err := graphQLJSNode(node)
+		return converted, err == nil, err
+	case reflect.Slice:
+		list := make([]interface{}, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			item, ok, err := graphQLJSValue(v.Index(i))
+			if err != nil {
+				return nil, false, err
+			}
+			if ok {
+				list = append(list, item)
+			}
+		}
+		return list, true, nil
+	case reflect.String, reflect.Bool:
+		return v.Interface(), true, nil
+	}
+	return nil, false, fmt.Errorf("unsupported value %v", v.Type())
+}
@@ -0,0 +1,148 @@
+package astutil_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql/language/astutil"
+)
+
+// graphQLJSQuery and graphQLJSOutput are a query and the JSON graphql-js
+// `parse` produces for it.
+const graphQLJSQuery = `query Q($id: ID = 4) { user(id: $id) { name ... on User { email @include(if: true) } } }`
+
+const graphQLJSOutput = `{
+  "kind": "Document",
+  "definitions": [{
+    "kind": "OperationDefinition",
+    "operation": "query",
+    "name": {"kind": "Name", "value": "Q", "loc": {"start": 6, "end": 7}},
+    "variableDefinitions": [{
+      "kind": "VariableDefinition",
+      "variable": {
+        "kind": "Variable",
+        "name": {"kind": "Name", "value": "id", "loc": {"start": 9, "end": 11}},
+        "loc": {"start": 8, "end": 11}
+      },
+      "type": {
+        "kind": "NamedType",
+        "name": {"kind": "Name", "value": "ID", "loc": {"start": 13, "end": 15}},
+        "loc": {"start": 13, "end": 15}
+      },
+      "defaultValue": {"kind": "IntValue", "value": "4", "loc": {"start": 18, "end": 19}},
+      "directives": [],
+      "loc": {"start": 8, "end": 19}
+    }],
+    "directives": [],
+    "selectionSet": {
+      "kind": "SelectionSet",
+      "selections": [{
+        "kind": "Field",
+        "name": {"kind": "Name", "value": "user", "loc": {"start": 23, "end": 27}},
+        "arguments": [{
+          "kind": "Argument",
+          "name": {"kind": "Name", "value": "id", "loc": {"start": 28, "end": 30}},
+          "value": {
+            "kind": "Variable",
+            "name": {"kind": "Name", "value": "id", "loc": {"start": 33, "end": 35}},
+            "loc": {"start": 32, "end": 35}
+          },
+          "loc": {"start": 28, "end": 35}
+        }],
+        "directives": [],
+        "selectionSet": {
+          "kind": "SelectionSet",
+          "selections": [{
+            "kind": "Field",
+            "name": {"kind": "Name", "value": "name", "loc": {"start": 39, "end": 43}},
+            "arguments": [],
+            "directives": [],
+            "loc": {"start": 39, "end": 43}
+          }, {
+            "kind": "InlineFragment",
+            "typeCondition": {
+              "kind": "NamedType",
+              "name": {"kind": "Name", "value": "User", "loc": {"start": 51, "end": 55}},
+              "loc": {"start": 51, "end": 55}
+            },
+            "directives": [],
+            "selectionSet": {
+              "kind": "SelectionSet",
+              "selections": [{
+                "kind": "Field",
+                "name": {"kind": "Name", "value": "email", "loc": {"start": 58, "end": 63}},
+                "arguments": [],
+                "directives": [{
+                  "kind": "Directive",
+                  "name": {"kind": "Name", "value": "include", "loc": {"start": 65, "end": 72}},
+                  "arguments": [{
+                    "kind": "Argument",
+                    "name": {"kind": "Name", "value": "if", "loc": {"start": 73, "end": 75}},
+                    "value": {"kind": "BooleanValue", "value": true, "loc": {"start": 77, "end": 81}},
+                    "loc": {"start": 73, "end": 81}
+                  }],
+                  "loc": {"start": 64, "end": 82}
+                }],
+                "loc": {"start": 58, "end": 82}
+              }],
+              "loc": {"start": 56, "end": 84}
+            },
+            "loc": {"start": 44, "end": 84}
+          }],
+          "loc": {"start": 37, "end": 86}
+        },
+        "loc": {"start": 23, "end": 86}
+      }],
+      "loc": {"start": 21, "end": 88}
+    },
+    "loc": {"start": 0, "end": 88}
+  }],
+  "loc": {"start": 0, "end": 88}
+}`
+
+func TestToGraphQLJSAST(t *testing.T) {
+	converted, err := astutil.ToGraphQLJSAST(parse(t, graphQLJSQuery))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	encoded, err := json.Marshal(converted)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got, expected interface{}
+	if err := json.Unmarshal(encoded, &got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := json.Unmarshal([]byte(graphQLJSOutput), &expected); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("unexpected AST, expected:\n%v\ngot:\n%s", graphQLJSOutput, encoded)
+	}
+}
+
+func TestToGraphQLJSAST_FlattensTypeExtensions(t *testing.T) {
+	converted, err := astutil.ToGraphQLJSAST(parse(t, `extend type User @cached { id: ID }`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	extension := converted["definitions"].([]interface{})[0].(map[string]interface{})
+	if extension["kind"] != "ObjectTypeExtension" {
+		t.Fatalf("unexpected kind: %v", extension["kind"])
+	}
+	for _, key := range []string{"name", "interfaces", "directives", "fields"} {
+		if _, ok := extension[key]; !ok {
+			t.Fatalf("expected the extension to have %q, got: %v", key, extension)
+		}
+	}
+	if _, ok := extension["definition"]; ok {
+		t.Fatalf("expected the extension to be flattened, got: %v", extension)
+	}
+}
+
+func TestToGraphQLJSAST_RejectsNilDocuments(t *testing.T) {
+	if _, err := astutil.ToGraphQLJSAST(nil); err == nil {
+		t.Fatalf("expected an error")
+	}
+}
@@ -0,0 +1,26 @@
+package astutil
+
+import (
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/visitor"
+)
+
+// LocationIndex returns the location of every node in doc, starting with the
+// document itself, in the order the nodes are entered, which is document
+// order. Nodes without a location, e.g. when parsed with NoLocation, are
+// left out.
+func LocationIndex(doc *ast.Document) []*ast.Location {
+	locations := []*ast.Location{}
+	if doc == nil {
+		return locations
+	}
+	visitor.Visit(doc, &visitor.VisitorOptions{
+		Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
+			if node, ok := p.Node.(ast.Node); ok && node.GetLoc() != nil {
+				locations = append(locations, node.GetLoc())
+			}
+			return visitor.ActionNoChange, nil
+		},
+	}, nil)
+	return locations
+}
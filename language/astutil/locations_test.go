@@ -0,0 +1,29 @@
+package astutil_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/astutil"
+)
+
+func TestLocationIndex(t *testing.T) {
+	doc := parse(t, `{ user(id: 1) { name } }`)
+	expected := []*ast.Location{
+		{Start: 0, End: 24},  // Document
+		{Start: 0, End: 24},  // OperationDefinition
+		{Start: 0, End: 24},  // SelectionSet
+		{Start: 2, End: 22},  // Field user
+		{Start: 2, End: 6},   // Name user
+		{Start: 7, End: 12},  // Argument id
+		{Start: 7, End: 9},   // Name id
+		{Start: 11, End: 12}, // IntValue 1
+		{Start: 14, End: 22}, // SelectionSet
+		{Start: 16, End: 20}, // Field name
+		{Start: 16, End: 20}, // Name name
+	}
+	if locations := astutil.LocationIndex(doc); !reflect.DeepEqual(locations, expected) {
+		t.Fatalf("unexpected locations, expected: %v, got: %v", expected, locations)
+	}
+}
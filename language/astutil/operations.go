@@ -0,0 +1,150 @@
+// Package astutil provides helpers for inspecting and rewriting parsed
+// GraphQL documents without a schema.
+package astutil
+
+import (
+	"reflect"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// MutationRootFields returns the names of the top-level fields selected by a
+// mutation operation, in selection order and without duplicates. Inline
+// fragments are expanded, as are fragment spreads defined in doc; doc may be
+// nil. Operations other than mutations yield an empty list.
+func MutationRootFields(doc *ast.Document, op *ast.OperationDefinition) []string {
+	return rootFieldNames(doc, op, ast.OperationTypeMutation)
+}
+
+// SubscriptionRootFieldNames returns the names of the top-level fields
+// selected by a subscription operation, expanded the same way as
+// MutationRootFields. Operations other than subscriptions yield an empty list.
+func SubscriptionRootFieldNames(doc *ast.Document, op *ast.OperationDefinition) []string {
+	return rootFieldNames(doc, op, ast.OperationTypeSubscription)
+}
+
+// OperationsEquivalent reports whether two operations are identical apart from
+// their names and source locations, e.g. the same query sent under different
+// operation names.
+func OperationsEquivalent(a, b *ast.OperationDefinition) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	a = deepCopy(a).(*ast.OperationDefinition)
+	b = deepCopy(b).(*ast.OperationDefinition)
+	a.Name, b.Name = nil, nil
+	clearLocations(a)
+	clearLocations(b)
+	return reflect.DeepEqual(a, b)
+}
+
+// OperationAt returns the operation whose source span contains the given byte
+// offset, e.g. to run the operation under an editor cursor. Operations parsed
+// with NoLocation are never matched.
+func OperationAt(doc *ast.Document, position int) (*ast.OperationDefinition, bool) {
+	if doc == nil {
+		return nil, false
+	}
+	for _, def := range doc.Definitions {
+		op, ok := def.(*ast.OperationDefinition)
+		if !ok || op.Loc == nil {
+			continue
+		}
+		if position >= op.Loc.Start && position < op.Loc.End {
+			return op, true
+		}
+	}
+	return nil, false
+}
+
+// OperationType returns the type of op: "query", "mutation" or
+// "subscription". Shorthand operations, and operations built without an
+// explicit type, are queries.
+func OperationType(op *ast.OperationDefinition) string {
+	if op != nil {
+		switch op.Operation {
+		case ast.OperationTypeMutation, ast.OperationTypeSubscription:
+			return op.Operation
+		}
+	}
+	return ast.OperationTypeQuery
+}
+
+// OperationsWithDirective returns the operations of doc that carry the named
+// directive on the operation itself, e.g. `query Q @persisted { ... }`, in
+// document order. Directives on fields and fragments are not considered.
+func OperationsWithDirective(doc *ast.Document, name string) []*ast.OperationDefinition {
+	ops := []*ast.OperationDefinition{}
+	if doc == nil {
+		return ops
+	}
+	for _, def := range doc.Definitions {
+		op, ok := def.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		for _, directive := range op.Directives {
+			if directive.Name != nil && directive.Name.Value == name {
+				ops = append(ops, op)
+				break
+			}
+		}
+	}
+	return ops
+}
+
+func rootFieldNames(doc *ast.Document, op *ast.OperationDefinition, operation string) []string {
+	names := []string{}
+	if op == nil || OperationType(op) != operation {
+		return names
+	}
+	fragments := fragmentDefinitions(doc)
+	seen := map[string]bool{}
+	visited := map[string]bool{}
+	var collect func(selectionSet *ast.SelectionSet)
+	collect = func(selectionSet *ast.SelectionSet) {
+		if selectionSet == nil {
+			return
+		}
+		for _, selection := range selectionSet.Selections {
+			switch selection := selection.(type) {
+			case *ast.Field:
+				if selection.Name == nil {
+					continue
+				}
+				name := selection.Name.Value
+				if !seen[name] {
+					seen[name] = true
+					names = append(names, name)
+				}
+			case *ast.InlineFragment:
+				collect(selection.SelectionSet)
+			case *ast.FragmentSpread:
+				if selection.Name == nil {
+					continue
+				}
+				name := selection.Name.Value
+				if fragment, ok := fragments[name]; ok && !visited[name] {
+					visited[name] = true
+					collect(fragment.SelectionSet)
+				}
+			}
+		}
+	}
+	collect(op.SelectionSet)
+	return names
+}
+
+// fragmentDefinitions indexes the fragment definitions of doc by name.
+func fragmentDefinitions(doc *ast.Document) map[string]*ast.FragmentDefinition {
+	fragments := map[string]*ast.FragmentDefinition{}
+	if doc == nil {
+		return fragments
+	}
+	for _, def := range doc.Definitions {
+		if fragment, ok := def.(*ast.FragmentDefinition); ok && fragment.Name != nil {
+			fragments[fragment.Name.Value] = fragment
+		}
+	}
+	return fragments
+}
@@ -0,0 +1,173 @@
+package astutil_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/astutil"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+func parse(t *testing.T, query string) *ast.Document {
+	astDoc, err := parser.Parse(parser.ParseParams{
+		Source: query,
+		Options: parser.ParseOptions{
+			NoSource: true,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	return astDoc
+}
+
+func operation(t *testing.T, doc *ast.Document, index int) *ast.OperationDefinition {
+	op, ok := doc.Definitions[index].(*ast.OperationDefinition)
+	if !ok {
+		t.Fatalf("definition %d is not an operation: %T", index, doc.Definitions[index])
+	}
+	return op
+}
+
+func TestMutationRootFields(t *testing.T) {
+	doc := parse(t, `
+mutation M {
+  createUser(name: "a") { id }
+  ...Extra
+}
+query Q { user { id } }
+fragment Extra on Mutation {
+  deleteUser(id: 1)
+  createUser(name: "b") { id }
+}
+`)
+	expected := []string{"createUser", "deleteUser"}
+	if names := astutil.MutationRootFields(doc, operation(t, doc, 0)); !reflect.DeepEqual(names, expected) {
+		t.Fatalf("unexpected root fields, expected: %v, got: %v", expected, names)
+	}
+	if names := astutil.MutationRootFields(doc, operation(t, doc, 1)); len(names) != 0 {
+		t.Fatalf("expected no root fields for a query, got: %v", names)
+	}
+
+	// nameless nodes of hand-built documents are skipped
+	op := operation(t, doc, 0)
+	op.SelectionSet.Selections = append(op.SelectionSet.Selections,
+		ast.NewField(&ast.Field{}),
+		ast.NewFragmentSpread(&ast.FragmentSpread{}),
+	)
+	if names := astutil.MutationRootFields(doc, op); !reflect.DeepEqual(names, expected) {
+		t.Fatalf("unexpected root fields, expected: %v, got: %v", expected, names)
+	}
+}
+
+func TestSubscriptionRootFieldNames(t *testing.T) {
+	doc := parse(t, `
+subscription S {
+  messageAdded(room: 1) { id }
+  ... on Subscription { userJoined { id } }
+}
+mutation M { sendMessage(room: 1) { id } }
+`)
+	expected := []string{"messageAdded", "userJoined"}
+	if names := astutil.SubscriptionRootFieldNames(doc, operation(t, doc, 0)); !reflect.DeepEqual(names, expected) {
+		t.Fatalf("unexpected root fields, expected: %v, got: %v", expected, names)
+	}
+	if names := astutil.SubscriptionRootFieldNames(doc, operation(t, doc, 1)); len(names) != 0 {
+		t.Fatalf("expected no root fields for a mutation, got: %v", names)
+	}
+}
+
+func TestOperationsEquivalent(t *testing.T) {
+	doc := parse(t, `
+query First($id: ID) { user(id: $id) { name } }
+query Second($id: ID) {
+  user(id: $id) {
+    name
+  }
+}
+query Third($id: ID) { user(id: $id) { name email } }
+`)
+	first, second, third := operation(t, doc, 0), operation(t, doc, 1), operation(t, doc, 2)
+	if !astutil.OperationsEquivalent(first, second) {
+		t.Fatalf("expected operations differing only by name to be equivalent")
+	}
+	if astutil.OperationsEquivalent(first, third) {
+		t.Fatalf("expected operations with different bodies not to be equivalent")
+	}
+	if first.Name.Value != "First" || first.Loc == nil {
+		t.Fatalf("comparison must not modify the operations")
+	}
+}
+
+func TestOperationAt(t *testing.T) {
+	body := `query A { a }
+fragment F on T { f }
+query B { b }`
+	doc := parse(t, body)
+	tests := []struct {
+		position int
+		name     string
+	}{
+		{0, "A"},
+		{12, "A"},
+		{13, ""},
+		{20, ""},
+		{36, "B"},
+		{len(body) - 1, "B"},
+		{len(body), ""},
+	}
+	for _, test := range tests {
+		op, ok := astutil.OperationAt(doc, test.position)
+		if test.name == "" {
+			if ok {
+				t.Fatalf("expected no operation at %d, got: %v", test.position, op.Name.Value)
+			}
+			continue
+		}
+		if !ok || op.Name.Value != test.name {
+			t.Fatalf("expected operation %v at %d, got: %v", test.name, test.position, op)
+		}
+	}
+}
+
+func TestOperationType(t *testing.T) {
+	doc := parse(t, `
+{ a }
+query Q { a }
+mutation M { a }
+subscription S { a }
+`)
+	expected := []string{"query", "query", "mutation", "subscription"}
+	for i, operationType := range expected {
+		if got := astutil.OperationType(operation(t, doc, i)); got != operationType {
+			t.Fatalf("unexpected type of operation %d, expected %q, got %q", i, operationType, got)
+		}
+	}
+	if got := astutil.OperationType(ast.NewOperationDefinition(nil)); got != "query" {
+		t.Fatalf("expected an operation without a type to be a query, got %q", got)
+	}
+	if got := astutil.OperationType(nil); got != "query" {
+		t.Fatalf("expected a nil operation to be a query, got %q", got)
+	}
+}
+
+func TestOperationsWithDirective(t *testing.T) {
+	doc := parse(t, `
+query A @persisted(id: "a") @cached { a }
+query B { b @persisted }
+mutation C @persisted @persisted { c }
+fragment F on T @persisted { f }
+subscription D @live { d }
+`)
+	names := []string{}
+	for _, op := range astutil.OperationsWithDirective(doc, "persisted") {
+		names = append(names, op.Name.Value)
+	}
+	if expected := []string{"A", "C"}; !reflect.DeepEqual(names, expected) {
+		t.Fatalf("expected operations %v, got: %v", expected, names)
+	}
+	if ops := astutil.OperationsWithDirective(doc, "unknown"); len(ops) != 0 {
+		t.Fatalf("expected no operations, got: %v", ops)
+	}
+}
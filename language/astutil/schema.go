@@ -0,0 +1,192 @@
+package astutil
+
+import (
+	"fmt"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// DefaultValues maps every defaulted input value in a schema document to its
+// default value node. Field arguments are keyed `Type.field.arg`, input object
+// fields `Input.field` and directive arguments `@directive.arg`.
+func DefaultValues(doc *ast.Document) map[string]ast.Value {
+	defaults := map[string]ast.Value{}
+	add := func(prefix string, inputValues []*ast.InputValueDefinition) {
+		for _, inputValue := range inputValues {
+			if inputValue.DefaultValue != nil && inputValue.Name != nil {
+				defaults[prefix+"."+inputValue.Name.Value] = inputValue.DefaultValue
+			}
+		}
+	}
+	addFields := func(typeName *ast.Name, fields []*ast.FieldDefinition) {
+		for _, field := range fields {
+			if typeName != nil && field.Name != nil {
+				add(typeName.Value+"."+field.Name.Value, field.Arguments)
+			}
+		}
+	}
+	if doc == nil {
+		return defaults
+	}
+	for _, def := range doc.Definitions {
+		switch def := def.(type) {
+		case *ast.ObjectDefinition:
+			addFields(def.Name, def.Fields)
+		case *ast.InterfaceDefinition:
+			addFields(def.Name, def.Fields)
+		case *ast.TypeExtensionDefinition:
+			if def.Definition != nil {
+				addFields(def.Definition.Name, def.Definition.Fields)
+			}
+		case *ast.InterfaceExtensionDefinition:
+			if def.Definition != nil {
+				addFields(def.Definition.Name, def.Definition.Fields)
+			}
+		case *ast.InputObjectDefinition:
+			if def.Name != nil {
+				add(def.Name.Value, def.Fields)
+			}
+		case *ast.DirectiveDefinition:
+			if def.Name != nil {
+				add("@"+def.Name.Value, def.Arguments)
+			}
+		}
+	}
+	return defaults
+}
+
+// RequiredArgumentsProvided reports every field selected in query that omits
+// an argument its definition in the schema document requires, i.e. one with
+// a non-null type and no default value. Types are resolved as for
+// DeprecatedFieldsUsed.
+func RequiredArgumentsProvided(schema, query *ast.Document) []error {
+	errs := []error{}
+	walkSchemaFields(schema, query, func(typeName string, field *ast.Field, def *ast.FieldDefinition) {
+		provided := map[string]bool{}
+		for _, arg := range field.Arguments {
+			if arg.Name != nil {
+				provided[arg.Name.Value] = true
+			}
+		}
+		for _, argDef := range def.Arguments {
+			if argDef.Name == nil || provided[argDef.Name.Value] || argDef.DefaultValue != nil {
+				continue
+			}
+			if _, ok := argDef.Type.(*ast.NonNull); ok {
+				errs = append(errs, newError(
+					fmt.Sprintf(`Field "%v" argument "%v" of type "%v" is required but not provided.`,
+						field.Name.Value, argDef.Name.Value, ast.TypeString(argDef.Type)),
+					[]ast.Node{field},
+				))
+			}
+		}
+	})
+	return errs
+}
+
+// walkSchemaFields calls fn for every field selected in query whose parent
+// type and field definition can be found in the schema document. Root types
+// come from the schema definition, defaulting to Query, Mutation and
+// Subscription. Fragments are walked once from their own type condition, so
+// spreads are not followed and each selection is visited once.
+func walkSchemaFields(schema, query *ast.Document, fn func(typeName string, field *ast.Field, def *ast.FieldDefinition)) {
+	if schema == nil || query == nil {
+		return
+	}
+	fields := map[string]map[string]*ast.FieldDefinition{}
+	addFields := func(typeName *ast.Name, defs []*ast.FieldDefinition) {
+		if typeName == nil {
+			return
+		}
+		if fields[typeName.Value] == nil {
+			fields[typeName.Value] = map[string]*ast.FieldDefinition{}
+		}
+		for _, def := range defs {
+			if def.Name != nil {
+				fields[typeName.Value][def.Name.Value] = def
+			}
+		}
+	}
+	roots := map[string]string{
+		ast.OperationTypeQuery:        "Query",
+		ast.OperationTypeMutation:     "Mutation",
+		ast.OperationTypeSubscription: "Subscription",
+	}
+	for _, def := range schema.Definitions {
+		switch def := def.(type) {
+		case *ast.SchemaDefinition:
+			for _, operationType := range def.OperationTypes {
+				if operationType.Type != nil && operationType.Type.Name != nil {
+					roots[operationType.Operation] = operationType.Type.Name.Value
+				}
+			}
+		case *ast.ObjectDefinition:
+			addFields(def.Name, def.Fields)
+		case *ast.InterfaceDefinition:
+			addFields(def.Name, def.Fields)
+		case *ast.TypeExtensionDefinition:
+			if def.Definition != nil {
+				addFields(def.Definition.Name, def.Definition.Fields)
+			}
+		case *ast.InterfaceExtensionDefinition:
+			if def.Definition != nil {
+				addFields(def.Definition.Name, def.Definition.Fields)
+			}
+		}
+	}
+
+	var walk func(typeName string, selectionSet *ast.SelectionSet)
+	walk = func(typeName string, selectionSet *ast.SelectionSet) {
+		if selectionSet == nil {
+			return
+		}
+		for _, selection := range selectionSet.Selections {
+			switch selection := selection.(type) {
+			case *ast.Field:
+				if selection.Name == nil {
+					continue
+				}
+				def, ok := fields[typeName][selection.Name.Value]
+				if !ok {
+					continue
+				}
+				fn(typeName, selection, def)
+				if named := namedType(def.Type); named != nil && named.Name != nil {
+					walk(named.Name.Value, selection.SelectionSet)
+				}
+			case *ast.InlineFragment:
+				fragmentType := typeName
+				if selection.TypeCondition != nil && selection.TypeCondition.Name != nil {
+					fragmentType = selection.TypeCondition.Name.Value
+				}
+				walk(fragmentType, selection.SelectionSet)
+			}
+		}
+	}
+	for _, def := range query.Definitions {
+		switch def := def.(type) {
+		case *ast.OperationDefinition:
+			walk(roots[def.Operation], def.SelectionSet)
+		case *ast.FragmentDefinition:
+			if def.TypeCondition != nil && def.TypeCondition.Name != nil {
+				walk(def.TypeCondition.Name.Value, def.SelectionSet)
+			}
+		}
+	}
+}
+
+// namedType unwraps list and non-null wrappers down to the named type.
+func namedType(ttype ast.Type) *ast.Named {
+	for {
+		switch t := ttype.(type) {
+		case *ast.Named:
+			return t
+		case *ast.List:
+			ttype = t.Type
+		case *ast.NonNull:
+			ttype = t.Type
+		default:
+			return nil
+		}
+	}
+}
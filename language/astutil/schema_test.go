@@ -0,0 +1,65 @@
+package astutil_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql/language/astutil"
+	"github.com/graphql-go/graphql/language/printer"
+)
+
+func TestDefaultValues(t *testing.T) {
+	doc := parse(t, `
+type Query {
+  users(first: Int = 10, after: String, order: Order = ASC): [User]
+}
+interface Node {
+  children(depth: Int = 1): [Node]
+}
+input Filter {
+  tags: [String] = ["a", "b"]
+  active: Boolean
+}
+directive @cost(weight: Int = 1) on FIELD_DEFINITION
+`)
+	expected := map[string]string{
+		"Query.users.first":   "10",
+		"Query.users.order":   "ASC",
+		"Node.children.depth": "1",
+		"Filter.tags":         `["a", "b"]`,
+		"@cost.weight":        "1",
+	}
+	defaults := astutil.DefaultValues(doc)
+	got := map[string]string{}
+	for key, value := range defaults {
+		got[key] = printer.Print(value).(string)
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("unexpected default values, expected: %v, got: %v", expected, got)
+	}
+}
+
+func TestRequiredArgumentsProvided(t *testing.T) {
+	schema := parse(t, `
+type Query {
+  user(id: ID!, locale: String! = "en", fields: [String]): User
+}
+type User {
+  avatar(size: Int!): String
+  name: String
+}
+`)
+	expectErrors(t, astutil.RequiredArgumentsProvided(schema, parse(t, `
+{
+  user(fields: ["name"]) { name avatar }
+}
+`)),
+		`Field "user" argument "id" of type "ID!" is required but not provided.`,
+		`Field "avatar" argument "size" of type "Int!" is required but not provided.`,
+	)
+	expectErrors(t, astutil.RequiredArgumentsProvided(schema, parse(t, `
+{
+  user(id: 1) { name ... on User { avatar(size: 64) } }
+}
+`)))
+}
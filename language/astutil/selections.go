@@ -0,0 +1,295 @@
+package astutil
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/kinds"
+	"github.com/graphql-go/graphql/language/printer"
+	"github.com/graphql-go/graphql/language/visitor"
+)
+
+// AliasesUsed returns every alias in the selection set, at any depth and in
+// document order. An alias reused in different places is reported each time,
+// so the length of the result is the number of aliased fields. Fragment
+// spreads are not followed.
+func AliasesUsed(selectionSet *ast.SelectionSet) []string {
+	aliases := []string{}
+	walkFields(selectionSet, func(field *ast.Field) {
+		if field.Alias != nil {
+			aliases = append(aliases, field.Alias.Value)
+		}
+	})
+	return aliases
+}
+
+// DeepestSelection returns the location and depth of the most deeply nested
+// field in doc; fields of an operation's or fragment's own selection set are
+// at depth 1 and inline fragments do not add a level. The first of several
+// equally deep fields wins. A document without fields yields (nil, 0).
+func DeepestSelection(doc *ast.Document) (*ast.Location, int) {
+	var (
+		deepest  *ast.Location
+		maxDepth int
+	)
+	var walk func(selectionSet *ast.SelectionSet, depth int)
+	walk = func(selectionSet *ast.SelectionSet, depth int) {
+		if selectionSet == nil {
+			return
+		}
+		for _, selection := range selectionSet.Selections {
+			switch selection := selection.(type) {
+			case *ast.Field:
+				if depth > maxDepth {
+					deepest, maxDepth = selection.Loc, depth
+				}
+				walk(selection.SelectionSet, depth+1)
+			case *ast.InlineFragment:
+				walk(selection.SelectionSet, depth)
+			}
+		}
+	}
+	if doc != nil {
+		for _, def := range doc.Definitions {
+			if def, ok := def.(ast.Definition); ok {
+				if selectionSet, ok := ast.SelectionSetOf(def); ok {
+					walk(selectionSet, 1)
+				}
+			}
+		}
+	}
+	return deepest, maxDepth
+}
+
+// FieldPaths returns every root-to-leaf path of field names through the
+// selection set, in document order. Inline fragments are expanded in place and
+// fragment spreads end a path as `...Name`; aliases are ignored.
+func FieldPaths(selectionSet *ast.SelectionSet) [][]string {
+	paths := [][]string{}
+	var walk func(selectionSet *ast.SelectionSet, prefix []string)
+	walk = func(selectionSet *ast.SelectionSet, prefix []string) {
+		if selectionSet == nil {
+			return
+		}
+		for _, selection := range selectionSet.Selections {
+			switch selection := selection.(type) {
+			case *ast.Field:
+				if selection.Name == nil {
+					continue
+				}
+				path := append(append([]string{}, prefix...), selection.Name.Value)
+				if selection.SelectionSet == nil || len(selection.SelectionSet.Selections) == 0 {
+					paths = append(paths, path)
+					continue
+				}
+				walk(selection.SelectionSet, path)
+			case *ast.InlineFragment:
+				walk(selection.SelectionSet, prefix)
+			case *ast.FragmentSpread:
+				if selection.Name == nil {
+					continue
+				}
+				paths = append(paths, append(append([]string{}, prefix...), "..."+selection.Name.Value))
+			}
+		}
+	}
+	walk(selectionSet, nil)
+	return paths
+}
+
+// walkFields calls fn for every field in the selection set, depth first,
+// descending into inline fragments and sub-selections.
+func walkFields(selectionSet *ast.SelectionSet, fn func(field *ast.Field)) {
+	if selectionSet == nil {
+		return
+	}
+	for _, selection := range selectionSet.Selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			fn(selection)
+			walkFields(selection.SelectionSet, fn)
+		case *ast.InlineFragment:
+			walkFields(selection.SelectionSet, fn)
+		}
+	}
+}
+
+// MostRepeatedSubtree returns the selection set that occurs most often in doc,
+// comparing selection sets structurally regardless of location, along with the
+// number of occurrences. It is a candidate for extraction into a fragment.
+// Ties go to the larger selection set, then to the first in document order.
+// When no selection set occurs twice it returns (nil, 0).
+func MostRepeatedSubtree(doc *ast.Document) (*ast.SelectionSet, int) {
+	if doc == nil {
+		return nil, 0
+	}
+	counts := map[string]int{}
+	order := []string{}
+	first := map[string]*ast.SelectionSet{}
+	visitor.Visit(doc, &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.SelectionSet: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					node, ok := p.Node.(*ast.SelectionSet)
+					if !ok || len(node.Selections) == 0 {
+						return visitor.ActionNoChange, nil
+					}
+					key, _ := printer.Print(node).(string)
+					if counts[key] == 0 {
+						order = append(order, key)
+						first[key] = node
+					}
+					counts[key]++
+					return visitor.ActionNoChange, nil
+				},
+			},
+		},
+	}, nil)
+
+	best := ""
+	for _, key := range order {
+		if counts[key] > counts[best] || (counts[key] == counts[best] && len(key) > len(best)) {
+			best = key
+		}
+	}
+	if counts[best] < 2 {
+		return nil, 0
+	}
+	return first[best], counts[best]
+}
+
+// SameResponseKeyDifferentArgs reports fields of one selection set that share
+// a response key but are given different arguments, e.g. `user(id: 1)` next
+// to `user(id: 2)`, which could not be merged into one result. Arguments are
+// compared by name and printed value, regardless of order. Each field is
+// compared against the first field selected under its response key.
+func SameResponseKeyDifferentArgs(doc *ast.Document) []error {
+	errs := []error{}
+	if doc == nil {
+		return errs
+	}
+	visitor.Visit(doc, &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.SelectionSet: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					node, ok := p.Node.(*ast.SelectionSet)
+					if !ok {
+						return visitor.ActionNoChange, nil
+					}
+					first := map[string]*ast.Field{}
+					for _, selection := range node.Selections {
+						field, ok := selection.(*ast.Field)
+						if !ok || field.Name == nil {
+							continue
+						}
+						key := field.Name.Value
+						if field.Alias != nil {
+							key = field.Alias.Value
+						}
+						seen, ok := first[key]
+						if !ok {
+							first[key] = field
+							continue
+						}
+						if argumentsKey(seen.Arguments) != argumentsKey(field.Arguments) {
+							errs = append(errs, newError(
+								fmt.Sprintf(`Fields "%v" conflict because they have differing arguments.`, key),
+								[]ast.Node{seen, field},
+							))
+						}
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+		},
+	}, nil)
+	return errs
+}
+
+// SuspiciousScalarSelections reports fields with a sub-selection whose name is
+// one of scalarNames, e.g. `createdAt { seconds }` when callers know createdAt
+// to be a scalar. It is a heuristic for linting documents without a schema.
+func SuspiciousScalarSelections(doc *ast.Document, scalarNames []string) []error {
+	errs := []error{}
+	if doc == nil || len(scalarNames) == 0 {
+		return errs
+	}
+	scalars := map[string]bool{}
+	for _, name := range scalarNames {
+		scalars[name] = true
+	}
+	visitor.Visit(doc, &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.Field: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					field, ok := p.Node.(*ast.Field)
+					if !ok || field.Name == nil || field.SelectionSet == nil || !scalars[field.Name.Value] {
+						return visitor.ActionNoChange, nil
+					}
+					errs = append(errs, newError(
+						fmt.Sprintf(`Field "%v" is a scalar but has a selection set.`, field.Name.Value),
+						[]ast.Node{field},
+					))
+					return visitor.ActionNoChange, nil
+				},
+			},
+		},
+	}, nil)
+	return errs
+}
+
+// argumentsKey renders arguments in name order so that argument lists can be
+// compared regardless of the order they were written in.
+func argumentsKey(args []*ast.Argument) string {
+	printed := []string{}
+	for _, arg := range args {
+		if arg.Name == nil {
+			continue
+		}
+		value, _ := printer.Print(arg.Value).(string)
+		printed = append(printed, arg.Name.Value+": "+value)
+	}
+	sort.Strings(printed)
+	return strings.Join(printed, ", ")
+}
+
+// FragmentTypeConditions returns the distinct type names used as type
+// conditions by the fragment definitions and inline fragments of doc, in
+// document order. These are the types a document selects polymorphically.
+func FragmentTypeConditions(doc *ast.Document) []string {
+	names := []string{}
+	if doc == nil {
+		return names
+	}
+	seen := map[string]bool{}
+	add := func(typeCondition *ast.Named) {
+		if typeCondition == nil || typeCondition.Name == nil || seen[typeCondition.Name.Value] {
+			return
+		}
+		seen[typeCondition.Name.Value] = true
+		names = append(names, typeCondition.Name.Value)
+	}
+	visitor.Visit(doc, &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.FragmentDefinition: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					if node, ok := p.Node.(*ast.FragmentDefinition); ok {
+						add(node.TypeCondition)
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+			kinds.InlineFragment: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					if node, ok := p.Node.(*ast.InlineFragment); ok {
+						add(node.TypeCondition)
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+		},
+	}, nil)
+	return names
+}
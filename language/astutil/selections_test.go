@@ -0,0 +1,199 @@
+package astutil_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/astutil"
+	"github.com/graphql-go/graphql/language/printer"
+)
+
+func TestAliasesUsed(t *testing.T) {
+	doc := parse(t, `
+{
+  first: user(id: 1) {
+    handle: name
+    friends { best: name }
+  }
+  second: user(id: 2) {
+    ... on User { handle: name }
+  }
+  plain
+}
+`)
+	expected := []string{"first", "handle", "best", "second", "handle"}
+	aliases := astutil.AliasesUsed(operation(t, doc, 0).SelectionSet)
+	if !reflect.DeepEqual(aliases, expected) {
+		t.Fatalf("unexpected aliases, expected: %v, got: %v", expected, aliases)
+	}
+}
+
+func TestDeepestSelection(t *testing.T) {
+	body := `{
+  a { b }
+  c {
+    d {
+      ... on T { deep }
+    }
+  }
+  e { f { g } }
+}`
+	doc := parse(t, body)
+	loc, depth := astutil.DeepestSelection(doc)
+	if depth != 3 {
+		t.Fatalf("expected depth 3, got: %v", depth)
+	}
+	if loc == nil {
+		t.Fatalf("expected a location")
+	}
+	if got := body[loc.Start:loc.End]; got != "deep" {
+		t.Fatalf("expected the deepest field to be `deep`, got: %q", got)
+	}
+}
+
+func TestFieldPaths(t *testing.T) {
+	doc := parse(t, `
+{
+  viewer {
+    name
+    friends {
+      name
+      ...Avatar
+    }
+  }
+  ... on Query {
+    node(id: 1) { id }
+  }
+  version
+}
+`)
+	expected := [][]string{
+		{"viewer", "name"},
+		{"viewer", "friends", "name"},
+		{"viewer", "friends", "...Avatar"},
+		{"node", "id"},
+		{"version"},
+	}
+	selectionSet := operation(t, doc, 0).SelectionSet
+	paths := astutil.FieldPaths(selectionSet)
+	if !reflect.DeepEqual(paths, expected) {
+		t.Fatalf("unexpected paths, expected: %v, got: %v", expected, paths)
+	}
+
+	// nameless nodes of hand-built documents are skipped
+	selectionSet.Selections = append(selectionSet.Selections,
+		ast.NewField(&ast.Field{}),
+		ast.NewFragmentSpread(&ast.FragmentSpread{}),
+	)
+	if paths := astutil.FieldPaths(selectionSet); !reflect.DeepEqual(paths, expected) {
+		t.Fatalf("unexpected paths, expected: %v, got: %v", expected, paths)
+	}
+}
+
+func TestMostRepeatedSubtree(t *testing.T) {
+	doc := parse(t, `
+{
+  me { avatar(size: 64) { url width } }
+  friends { avatar(size: 64) { url width } }
+  node(id: 1) {
+    ... on User {
+      avatar(size: 64) {
+        url
+        width
+      }
+    }
+  }
+  owner { name }
+}
+fragment F on User { owner { name } }
+`)
+	subtree, count := astutil.MostRepeatedSubtree(doc)
+	if count != 3 {
+		t.Fatalf("expected a subtree repeated 3 times, got: %d", count)
+	}
+	// `{ url width }` occurs as often, but the enclosing subtree is larger
+	expected := "{\n  avatar(size: 64) {\n    url\n    width\n  }\n}"
+	if printed := printer.Print(subtree); printed != expected {
+		t.Fatalf("unexpected subtree, expected:\n%v\ngot:\n%v", expected, printed)
+	}
+	me := operation(t, doc, 0).SelectionSet.Selections[0].(*ast.Field)
+	if subtree != me.SelectionSet {
+		t.Fatalf("expected the first occurrence, got: %v", subtree.Loc)
+	}
+
+	if subtree, count := astutil.MostRepeatedSubtree(parse(t, `{ a { b } c { d } }`)); subtree != nil || count != 0 {
+		t.Fatalf("expected no repeated subtree, got: %v, %d", subtree, count)
+	}
+}
+
+func TestFragmentTypeConditions(t *testing.T) {
+	doc := parse(t, `
+{
+  search {
+    ... on Photo { url }
+    ... { id }
+    ...PersonFields
+  }
+}
+fragment PersonFields on Person {
+  name
+  pets { ... on Photo { url } }
+}
+`)
+	expected := []string{"Photo", "Person"}
+	if names := astutil.FragmentTypeConditions(doc); !reflect.DeepEqual(names, expected) {
+		t.Fatalf("unexpected type conditions, expected: %v, got: %v", expected, names)
+	}
+}
+
+func TestSameResponseKeyDifferentArgs(t *testing.T) {
+	doc := parse(t, `
+{
+  user(id: 1) { name }
+  user(id: 2) { name }
+  me: user(id: 3) { name }
+  me: user { name }
+  friends(first: 10) { name }
+  friends(first: 10) { avatar(size: 32) avatar(size: 64) }
+}
+`)
+	expectErrors(t, astutil.SameResponseKeyDifferentArgs(doc),
+		`Fields "user" conflict because they have differing arguments.`,
+		`Fields "me" conflict because they have differing arguments.`,
+		`Fields "avatar" conflict because they have differing arguments.`,
+	)
+}
+
+func TestSameResponseKeyDifferentArgs_AcceptsIdenticalArguments(t *testing.T) {
+	doc := parse(t, `
+query Q($id: ID) {
+  user(id: $id, active: true) { name }
+  user(active: true, id: $id) { email }
+  friends { name }
+  friends { email }
+  byId: user(id: 1) { name }
+  byName: user(name: "x") { name }
+}
+`)
+	expectErrors(t, astutil.SameResponseKeyDifferentArgs(doc))
+}
+
+func TestSuspiciousScalarSelections(t *testing.T) {
+	doc := parse(t, `
+{
+  user {
+    createdAt { seconds }
+    updatedAt
+    friends { createdAt }
+  }
+}
+fragment F on Post { id { value } }
+`)
+	expectErrors(t, astutil.SuspiciousScalarSelections(doc, []string{"createdAt", "updatedAt", "id"}),
+		`Field "createdAt" is a scalar but has a selection set.`,
+		`Field "id" is a scalar but has a selection set.`,
+	)
+	expectErrors(t, astutil.SuspiciousScalarSelections(doc, []string{"name"}))
+	expectErrors(t, astutil.SuspiciousScalarSelections(doc, nil))
+}
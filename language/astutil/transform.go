@@ -0,0 +1,167 @@
+package astutil
+
+import (
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/kinds"
+	"github.com/graphql-go/graphql/language/visitor"
+)
+
+// SubstituteVariables returns a copy of doc in which every variable used as
+// an argument value, at any depth inside list and object values, is replaced
+// by the literal vars maps its name to. Variables missing from vars are kept,
+// as are the operations' variable definitions. doc itself is not modified.
+func SubstituteVariables(doc *ast.Document, vars map[string]ast.Value) *ast.Document {
+	if doc == nil {
+		return nil
+	}
+	doc = deepCopy(doc).(*ast.Document)
+	var (
+		arguments    []*ast.Argument
+		objectFields []*ast.ObjectField
+		lists        []*ast.ListValue
+	)
+	visitor.Visit(doc, &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.VariableDefinition: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					return visitor.ActionSkip, nil
+				},
+			},
+			kinds.Argument: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					if node, ok := p.Node.(*ast.Argument); ok {
+						arguments = append(arguments, node)
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+			kinds.ObjectField: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					if node, ok := p.Node.(*ast.ObjectField); ok {
+						objectFields = append(objectFields, node)
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+			kinds.ListValue: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					if node, ok := p.Node.(*ast.ListValue); ok {
+						lists = append(lists, node)
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+		},
+	}, nil)
+
+	// Substitute only once the walk is done so that replacement literals are
+	// never themselves rewritten.
+	substitute := func(value ast.Value) ast.Value {
+		variable, ok := value.(*ast.Variable)
+		if !ok || variable.Name == nil {
+			return value
+		}
+		if literal, ok := vars[variable.Name.Value]; ok {
+			return literal
+		}
+		return value
+	}
+	for _, node := range arguments {
+		node.Value = substitute(node.Value)
+	}
+	for _, node := range objectFields {
+		node.Value = substitute(node.Value)
+	}
+	for _, node := range lists {
+		for i, value := range node.Values {
+			node.Values[i] = substitute(value)
+		}
+	}
+	return doc
+}
+
+// FlattenRedundantInlineFragments returns a copy of doc in which every inline
+// fragment without a type condition or directives is replaced by its own
+// selections, e.g. `{ a ... { b } }` becomes `{ a b }`. doc itself is not
+// modified.
+func FlattenRedundantInlineFragments(doc *ast.Document) *ast.Document {
+	if doc == nil {
+		return nil
+	}
+	doc = deepCopy(doc).(*ast.Document)
+	selectionSets := []*ast.SelectionSet{}
+	visitor.Visit(doc, &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.SelectionSet: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					if node, ok := p.Node.(*ast.SelectionSet); ok {
+						selectionSets = append(selectionSets, node)
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+		},
+	}, nil)
+
+	var flatten func(selections []ast.Selection) []ast.Selection
+	flatten = func(selections []ast.Selection) []ast.Selection {
+		flattened := []ast.Selection{}
+		for _, selection := range selections {
+			fragment, ok := selection.(*ast.InlineFragment)
+			if !ok || fragment.TypeCondition != nil || len(fragment.Directives) > 0 {
+				flattened = append(flattened, selection)
+				continue
+			}
+			if fragment.SelectionSet != nil {
+				flattened = append(flattened, flatten(fragment.SelectionSet.Selections)...)
+			}
+		}
+		return flattened
+	}
+	for _, node := range selectionSets {
+		node.Selections = flatten(node.Selections)
+	}
+	return doc
+}
+
+// AddDirectiveToFields returns a copy of doc in which a copy of dir is
+// appended to the directives of every field pred selects, e.g. to add
+// `@trace` to specific fields. doc and dir are not modified.
+func AddDirectiveToFields(doc *ast.Document, pred func(field *ast.Field) bool, dir *ast.Directive) *ast.Document {
+	if doc == nil {
+		return nil
+	}
+	doc = deepCopy(doc).(*ast.Document)
+	if dir == nil || pred == nil {
+		return doc
+	}
+	fields := []*ast.Field{}
+	visitor.Visit(doc, &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.Field: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					if node, ok := p.Node.(*ast.Field); ok && pred(node) {
+						fields = append(fields, node)
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+		},
+	}, nil)
+	for _, field := range fields {
+		field.Directives = append(field.Directives, deepCopy(dir).(*ast.Directive))
+	}
+	return doc
+}
+
+// ForSerialization returns a copy of doc without locations, so it carries no
+// pointers to the source it was parsed from and can be gob- or JSON-encoded
+// without leaking the source body. doc is not modified.
+func ForSerialization(doc *ast.Document) *ast.Document {
+	if doc == nil {
+		return nil
+	}
+	doc = deepCopy(doc).(*ast.Document)
+	clearLocations(doc)
+	return doc
+}
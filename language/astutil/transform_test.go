@@ -0,0 +1,158 @@
+package astutil_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/astutil"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/printer"
+)
+
+func TestSubstituteVariables(t *testing.T) {
+	doc := parse(t, `query Q($id: ID, $tags: [String], $limit: Int) {
+  user(id: $id) {
+    posts(filter: {tags: [$tags, "x"]}, first: $limit) @include(if: $show)
+  }
+}`)
+	substituted := astutil.SubstituteVariables(doc, map[string]ast.Value{
+		"id":   ast.NewIntValue(&ast.IntValue{Value: "4"}),
+		"tags": ast.NewStringValue(&ast.StringValue{Value: "go"}),
+		"show": ast.NewBooleanValue(&ast.BooleanValue{Value: true}),
+	})
+	expected := `query Q($id: ID, $tags: [String], $limit: Int) {
+  user(id: 4) {
+    posts(filter: {tags: ["go", "x"]}, first: $limit) @include(if: true)
+  }
+}
+`
+	if printed := printer.Print(substituted); printed != expected {
+		t.Fatalf("unexpected document, expected:\n%v\ngot:\n%v", expected, printed)
+	}
+	// the original document is left untouched
+	original := `query Q($id: ID, $tags: [String], $limit: Int) {
+  user(id: $id) {
+    posts(filter: {tags: [$tags, "x"]}, first: $limit) @include(if: $show)
+  }
+}
+`
+	if printed := printer.Print(doc); printed != original {
+		t.Fatalf("original document was modified, expected:\n%v\ngot:\n%v", original, printed)
+	}
+}
+
+func TestFlattenRedundantInlineFragments(t *testing.T) {
+	doc := parse(t, `{
+  user {
+    id
+    ... {
+      name
+      ... {
+        email
+      }
+    }
+    ... on Admin {
+      ... {
+        level
+      }
+    }
+    ... @include(if: true) {
+      avatar
+    }
+  }
+}`)
+	flattened := astutil.FlattenRedundantInlineFragments(doc)
+	expected := `{
+  user {
+    id
+    name
+    email
+    ... on Admin {
+      level
+    }
+    ... @include(if: true) {
+      avatar
+    }
+  }
+}
+`
+	if printed := printer.Print(flattened); printed != expected {
+		t.Fatalf("unexpected document, expected:\n%v\ngot:\n%v", expected, printed)
+	}
+	if printed := printer.Print(doc); printed == expected {
+		t.Fatalf("original document was modified")
+	}
+}
+
+func TestAddDirectiveToFields(t *testing.T) {
+	doc := parse(t, `{
+  user(id: 1) @include(if: true) {
+    name
+    friends {
+      user {
+        name
+      }
+    }
+  }
+}`)
+	trace := ast.NewDirective(&ast.Directive{
+		Name: ast.NewName(&ast.Name{Value: "trace"}),
+		Arguments: []*ast.Argument{
+			ast.NewArgument(&ast.Argument{
+				Name:  ast.NewName(&ast.Name{Value: "label"}),
+				Value: ast.NewStringValue(&ast.StringValue{Value: "user"}),
+			}),
+		},
+	})
+	traced := astutil.AddDirectiveToFields(doc, func(field *ast.Field) bool {
+		return field.Name.Value == "user"
+	}, trace)
+	expected := `{
+  user(id: 1) @include(if: true) @trace(label: "user") {
+    name
+    friends {
+      user @trace(label: "user") {
+        name
+      }
+    }
+  }
+}
+`
+	if printed := printer.Print(traced); printed != expected {
+		t.Fatalf("unexpected document, expected:\n%v\ngot:\n%v", expected, printed)
+	}
+	if printed := printer.Print(doc); printed == expected {
+		t.Fatalf("original document was modified")
+	}
+}
+
+func TestForSerialization(t *testing.T) {
+	body := `query SecretQuery($id: ID = "4") { user(id: $id) @include(if: true) { ...F } }
+fragment F on User { name }`
+	doc, err := parser.Parse(parser.ParseParams{Source: body})
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	serializable := astutil.ForSerialization(doc)
+	encoded, err := json.Marshal(serializable)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(string(encoded), `"Source"`) || strings.Contains(string(encoded), "SecretQuery(") {
+		t.Fatalf("expected no source in the serialized document, got: %s", encoded)
+	}
+	if strings.Contains(string(encoded), `"Loc":{`) {
+		t.Fatalf("expected no locations in the serialized document, got: %s", encoded)
+	}
+	if printer.Print(serializable) != printer.Print(doc) {
+		t.Fatalf("expected the copy to print like the original")
+	}
+	if doc.Loc == nil || doc.Loc.Source == nil {
+		t.Fatalf("expected the original document to keep its location")
+	}
+	if astutil.ForSerialization(nil) != nil {
+		t.Fatalf("expected nil for a nil document")
+	}
+}
@@ -0,0 +1,50 @@
+package astutil
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/kinds"
+	"github.com/graphql-go/graphql/language/visitor"
+)
+
+var nameRegExp = regexp.MustCompile("^[_a-zA-Z][_a-zA-Z0-9]*$")
+
+func newError(message string, nodes []ast.Node) *gqlerrors.Error {
+	return gqlerrors.NewError(
+		message,
+		nodes,
+		"",
+		nil,
+		[]int{},
+		nil,
+	)
+}
+
+// ValidNames reports every name in doc that does not match the GraphQL name
+// grammar. Parsed documents always pass; this guards ASTs that were built or
+// rewritten programmatically before they are printed.
+func ValidNames(doc *ast.Document) []error {
+	errs := []error{}
+	if doc == nil {
+		return errs
+	}
+	visitor.Visit(doc, &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.Name: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					if name, ok := p.Node.(*ast.Name); ok && !nameRegExp.MatchString(name.Value) {
+						errs = append(errs, newError(
+							fmt.Sprintf(`Names must match /^[_a-zA-Z][_a-zA-Z0-9]*$/ but "%v" does not.`, name.Value),
+							[]ast.Node{name},
+						))
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+		},
+	}, nil)
+	return errs
+}
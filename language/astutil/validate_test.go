@@ -0,0 +1,53 @@
+package astutil_test
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/astutil"
+)
+
+func expectErrors(t *testing.T, errs []error, expected ...string) {
+	if len(errs) != len(expected) {
+		t.Fatalf("expected %d errors, got %d: %v", len(expected), len(errs), errs)
+	}
+	for i, err := range errs {
+		if err.Error() != expected[i] {
+			t.Fatalf("unexpected error %d, expected: %v, got: %v", i, expected[i], err.Error())
+		}
+	}
+}
+
+func TestValidNames_AcceptsParsedDocument(t *testing.T) {
+	doc := parse(t, `
+query Q($id: ID) { user(id: $id) { ...F } }
+fragment F on User { _name: name }
+`)
+	expectErrors(t, astutil.ValidNames(doc))
+	expectErrors(t, astutil.ValidNames(nil))
+}
+
+func TestValidNames_ReportsConstructedInvalidNames(t *testing.T) {
+	doc := ast.NewDocument(&ast.Document{
+		Definitions: []ast.Node{
+			ast.NewOperationDefinition(&ast.OperationDefinition{
+				Operation: ast.OperationTypeQuery,
+				Name:      ast.NewName(&ast.Name{Value: "1stQuery"}),
+				SelectionSet: ast.NewSelectionSet(&ast.SelectionSet{
+					Selections: []ast.Selection{
+						ast.NewField(&ast.Field{
+							Name: ast.NewName(&ast.Name{Value: "user-name"}),
+						}),
+						ast.NewField(&ast.Field{
+							Name: ast.NewName(&ast.Name{Value: "ok"}),
+						}),
+					},
+				}),
+			}),
+		},
+	})
+	expectErrors(t, astutil.ValidNames(doc),
+		`Names must match /^[_a-zA-Z][_a-zA-Z0-9]*$/ but "1stQuery" does not.`,
+		`Names must match /^[_a-zA-Z][_a-zA-Z0-9]*$/ but "user-name" does not.`,
+	)
+}
@@ -0,0 +1,254 @@
+package astutil
+
+import (
+	"fmt"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/kinds"
+	"github.com/graphql-go/graphql/language/visitor"
+)
+
+// UsesVariables reports whether op declares or references any variable, e.g.
+// to decide whether a request needs a variables payload at all. Variables
+// referenced only from fragments spread into op are not seen.
+func UsesVariables(op *ast.OperationDefinition) bool {
+	if op == nil {
+		return false
+	}
+	if len(op.VariableDefinitions) > 0 {
+		return true
+	}
+	found := false
+	visitor.Visit(op, &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.Variable: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					found = true
+					return visitor.ActionBreak, nil
+				},
+			},
+		},
+	}, nil)
+	return found
+}
+
+// PruneVariables returns a copy of op without the variable definitions its
+// body no longer references, e.g. after SubstituteVariables inlined them or a
+// field using them was skipped and removed. Variables referenced from
+// fragments defined in doc and spread into op, directly or transitively, are
+// kept; doc may be nil. op is not modified.
+func PruneVariables(doc *ast.Document, op *ast.OperationDefinition) *ast.OperationDefinition {
+	if op == nil {
+		return nil
+	}
+	op = deepCopy(op).(*ast.OperationDefinition)
+	fragments := fragmentDefinitions(doc)
+	used := map[string]bool{}
+	visited := map[string]bool{}
+	pending := []ast.Node{op}
+	for len(pending) > 0 {
+		node := pending[0]
+		pending = pending[1:]
+		visitor.Visit(node, &visitor.VisitorOptions{
+			KindFuncMap: map[string]visitor.NamedVisitFuncs{
+				kinds.VariableDefinition: {
+					Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+						return visitor.ActionSkip, nil
+					},
+				},
+				kinds.Variable: {
+					Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+						if node, ok := p.Node.(*ast.Variable); ok && node.Name != nil {
+							used[node.Name.Value] = true
+						}
+						return visitor.ActionNoChange, nil
+					},
+				},
+				kinds.FragmentSpread: {
+					Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+						spread, ok := p.Node.(*ast.FragmentSpread)
+						if !ok || spread.Name == nil || visited[spread.Name.Value] {
+							return visitor.ActionNoChange, nil
+						}
+						visited[spread.Name.Value] = true
+						if fragment, ok := fragments[spread.Name.Value]; ok {
+							pending = append(pending, fragment)
+						}
+						return visitor.ActionNoChange, nil
+					},
+				},
+			},
+		}, nil)
+	}
+	defs := []*ast.VariableDefinition{}
+	for _, def := range op.VariableDefinitions {
+		if def.Variable != nil && def.Variable.Name != nil && used[def.Variable.Name.Value] {
+			defs = append(defs, def)
+		}
+	}
+	op.VariableDefinitions = defs
+	return op
+}
+
+// VariablesInConstPositions reports variables used where only constant values
+// are allowed: in default values of variables, arguments and input fields, and
+// in directives applied to type system definitions. The parser already rejects
+// these, so this guards ASTs that were built or rewritten programmatically.
+func VariablesInConstPositions(doc *ast.Document) []error {
+	errs := []error{}
+	if doc == nil {
+		return errs
+	}
+	visitor.Visit(doc, &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.Variable: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					variable, ok := p.Node.(*ast.Variable)
+					if !ok || variable.Name == nil {
+						return visitor.ActionNoChange, nil
+					}
+					if def, ok := p.Parent.(*ast.VariableDefinition); ok && def.Variable == variable {
+						return visitor.ActionNoChange, nil
+					}
+					if inConstPosition(append(p.Ancestors, p.Parent)) {
+						errs = append(errs, newError(
+							fmt.Sprintf(`Variable "$%v" is not allowed in a constant position.`, variable.Name.Value),
+							[]ast.Node{variable},
+						))
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+		},
+	}, nil)
+	return errs
+}
+
+// inConstPosition reports whether a value with the given ancestors must be
+// constant: it sits in a default value or outside any executable definition.
+func inConstPosition(ancestors []ast.Node) bool {
+	definition := ast.Node(nil)
+	for _, ancestor := range ancestors {
+		switch ancestor.(type) {
+		case nil, *ast.Document:
+			continue
+		case *ast.VariableDefinition, *ast.InputValueDefinition:
+			return true
+		}
+		if definition == nil {
+			definition = ancestor
+		}
+	}
+	switch definition.(type) {
+	case *ast.OperationDefinition, *ast.FragmentDefinition:
+		return false
+	}
+	return definition != nil
+}
+
+// ConstValuesHaveNoVariables reports variables found at any depth inside the
+// default values of variable definitions and of arguments and input fields,
+// including those of directive definitions. Unlike VariablesInConstPositions
+// it looks at nothing but default values.
+func ConstValuesHaveNoVariables(doc *ast.Document) []error {
+	errs := []error{}
+	if doc == nil {
+		return errs
+	}
+	check := func(value ast.Value) {
+		walkValueVariables(value, func(variable *ast.Variable) {
+			errs = append(errs, newError(
+				fmt.Sprintf(`Default values must be constant, found variable "$%v".`, variable.Name.Value),
+				[]ast.Node{variable},
+			))
+		})
+	}
+	visitor.Visit(doc, &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.VariableDefinition: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					if def, ok := p.Node.(*ast.VariableDefinition); ok {
+						check(def.DefaultValue)
+					}
+					return visitor.ActionSkip, nil
+				},
+			},
+			kinds.InputValueDefinition: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					if def, ok := p.Node.(*ast.InputValueDefinition); ok {
+						check(def.DefaultValue)
+					}
+					return visitor.ActionSkip, nil
+				},
+			},
+		},
+	}, nil)
+	return errs
+}
+
+// walkValueVariables calls fn with every named variable nested in value.
+func walkValueVariables(value ast.Value, fn func(variable *ast.Variable)) {
+	switch value := value.(type) {
+	case *ast.Variable:
+		if value.Name != nil {
+			fn(value)
+		}
+	case *ast.ListValue:
+		for _, item := range value.Values {
+			walkValueVariables(item, fn)
+		}
+	case *ast.ObjectValue:
+		for _, field := range value.Fields {
+			if field != nil {
+				walkValueVariables(field.Value, fn)
+			}
+		}
+	}
+}
+
+// ConflictingVariables reports variables that are declared by name in more
+// than one of ops with different types, e.g. `$id: Int` and `$id: String`,
+// which prevents the operations from being merged into one request. Each
+// declaration is compared against the first one seen for that name.
+func ConflictingVariables(ops ...*ast.OperationDefinition) []error {
+	errs := []error{}
+	type declaration struct {
+		op  *ast.OperationDefinition
+		def *ast.VariableDefinition
+	}
+	first := map[string]declaration{}
+	for _, op := range ops {
+		if op == nil {
+			continue
+		}
+		for _, def := range op.VariableDefinitions {
+			if def.Variable == nil || def.Variable.Name == nil {
+				continue
+			}
+			name := def.Variable.Name.Value
+			seen, ok := first[name]
+			if !ok {
+				first[name] = declaration{op, def}
+				continue
+			}
+			if ast.TypeString(seen.def.Type) == ast.TypeString(def.Type) {
+				continue
+			}
+			errs = append(errs, newError(
+				fmt.Sprintf(`Variable "$%v" is declared as "%v" in %v and as "%v" in %v.`,
+					name, ast.TypeString(seen.def.Type), operationLabel(seen.op),
+					ast.TypeString(def.Type), operationLabel(op)),
+				[]ast.Node{seen.def, def},
+			))
+		}
+	}
+	return errs
+}
+
+// operationLabel names an operation for use in error messages.
+func operationLabel(op *ast.OperationDefinition) string {
+	if op.Name == nil {
+		return "an anonymous operation"
+	}
+	return fmt.Sprintf(`operation "%v"`, op.Name.Value)
+}
@@ -0,0 +1,158 @@
+package astutil_test
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/astutil"
+	"github.com/graphql-go/graphql/language/printer"
+)
+
+func TestConflictingVariables(t *testing.T) {
+	doc := parse(t, `
+query A($id: Int, $first: Int) { user(id: $id) { friends(first: $first) } }
+query B($id: String, $first: Int) { node(id: $id) }
+query ($id: [Int]) { users(ids: $id) }
+`)
+	expectErrors(t, astutil.ConflictingVariables(operation(t, doc, 0), operation(t, doc, 1), operation(t, doc, 2)),
+		`Variable "$id" is declared as "Int" in operation "A" and as "String" in operation "B".`,
+		`Variable "$id" is declared as "Int" in operation "A" and as "[Int]" in an anonymous operation.`,
+	)
+}
+
+func TestConflictingVariables_AcceptsCompatibleOperations(t *testing.T) {
+	doc := parse(t, `
+query A($id: ID!, $first: Int = 10) { user(id: $id) { friends(first: $first) } }
+query B($id: ID!, $after: String) { node(id: $id) { ... on User { friends(after: $after) } } }
+`)
+	expectErrors(t, astutil.ConflictingVariables(operation(t, doc, 0), operation(t, doc, 1)))
+}
+
+func TestUsesVariables(t *testing.T) {
+	doc := parse(t, `
+query Declared($unused: Int) { me { name } }
+query Referenced { user(id: $id) { name } }
+query Nested { me { friends(first: 10) { avatar(size: {width: $w}) } } }
+query Fixed { user(id: 4) { name @include(if: true) } }
+{ me { ...Fields } }
+fragment Fields on User { friends(first: $first) }
+`)
+	tests := []struct {
+		index    int
+		expected bool
+	}{
+		{0, true},
+		{1, true},
+		{2, true},
+		{3, false},
+		{4, false},
+	}
+	for _, test := range tests {
+		if actual := astutil.UsesVariables(operation(t, doc, test.index)); actual != test.expected {
+			t.Fatalf("expected UsesVariables of operation %d to be %v, got: %v", test.index, test.expected, actual)
+		}
+	}
+	if astutil.UsesVariables(nil) {
+		t.Fatalf("expected a nil operation to use no variables")
+	}
+}
+
+func TestVariablesInConstPositions(t *testing.T) {
+	doc := parse(t, `
+query Q($first: Int = 10, $id: ID) { user(id: $id) { friends(first: $first) @include(if: $id) } }
+directive @limit(max: Int = 100) on FIELD
+type Query @cache(ttl: 60) { users(first: Int = 10): [String] }
+`)
+	expectErrors(t, astutil.VariablesInConstPositions(doc))
+
+	op := operation(t, doc, 0)
+	op.VariableDefinitions[0].DefaultValue = variable("other")
+	directive := doc.Definitions[1].(*ast.DirectiveDefinition)
+	directive.Arguments[0].DefaultValue = ast.NewListValue(&ast.ListValue{
+		Values: []ast.Value{variable("max")},
+	})
+	object := doc.Definitions[2].(*ast.ObjectDefinition)
+	object.Directives[0].Arguments[0].Value = variable("ttl")
+	expectErrors(t, astutil.VariablesInConstPositions(doc),
+		`Variable "$other" is not allowed in a constant position.`,
+		`Variable "$max" is not allowed in a constant position.`,
+		`Variable "$ttl" is not allowed in a constant position.`,
+	)
+	expectErrors(t, astutil.VariablesInConstPositions(nil))
+}
+
+func TestConstValuesHaveNoVariables(t *testing.T) {
+	doc := parse(t, `
+query Q($ids: [ID] = [1, 2], $filter: Filter = {tags: ["a"], range: {min: 1}}) { user { name } }
+directive @limit(max: Int = 100) on FIELD
+type Query { users(first: Int = 10, where: Filter = {tags: []}): [String] }
+input Filter { tags: [String] = ["x"], range: Range }
+`)
+	expectErrors(t, astutil.ConstValuesHaveNoVariables(doc))
+
+	op := operation(t, doc, 0)
+	op.VariableDefinitions[0].DefaultValue.(*ast.ListValue).Values[1] = ast.NewListValue(&ast.ListValue{
+		Values: []ast.Value{variable("id")},
+	})
+	filter := op.VariableDefinitions[1].DefaultValue.(*ast.ObjectValue)
+	filter.Fields[1].Value.(*ast.ObjectValue).Fields[0].Value = variable("min")
+	directive := doc.Definitions[1].(*ast.DirectiveDefinition)
+	directive.Arguments[0].DefaultValue = variable("max")
+	input := doc.Definitions[3].(*ast.InputObjectDefinition)
+	input.Fields[0].DefaultValue.(*ast.ListValue).Values[0] = variable("tag")
+	expectErrors(t, astutil.ConstValuesHaveNoVariables(doc),
+		`Default values must be constant, found variable "$id".`,
+		`Default values must be constant, found variable "$min".`,
+		`Default values must be constant, found variable "$max".`,
+		`Default values must be constant, found variable "$tag".`,
+	)
+	expectErrors(t, astutil.ConstValuesHaveNoVariables(nil))
+}
+
+func variable(name string) *ast.Variable {
+	return ast.NewVariable(&ast.Variable{
+		Name: ast.NewName(&ast.Name{Value: name}),
+	})
+}
+
+func TestPruneVariables(t *testing.T) {
+	doc := parse(t, `query Q($id: ID, $hide: Boolean, $first: Int, $unused: String) @cached(ttl: $first) {
+  me { name }
+  user(id: $id) @skip(if: $hide) { name }
+}`)
+	// inline the known condition, then drop the skipped field
+	doc = astutil.SubstituteVariables(doc, map[string]ast.Value{
+		"hide": ast.NewBooleanValue(&ast.BooleanValue{Value: true}),
+	})
+	op := operation(t, doc, 0)
+	op.SelectionSet.Selections = op.SelectionSet.Selections[:1]
+
+	pruned := astutil.PruneVariables(doc, op)
+	expected := `query Q($first: Int) @cached(ttl: $first) {
+  me {
+    name
+  }
+}`
+	if printed := printer.Print(pruned); printed != expected {
+		t.Fatalf("unexpected operation, expected:\n%v\ngot:\n%v", expected, printed)
+	}
+	if len(op.VariableDefinitions) != 4 {
+		t.Fatalf("expected the original operation to keep its variables, got: %v", op.VariableDefinitions)
+	}
+	if astutil.PruneVariables(doc, nil) != nil {
+		t.Fatalf("expected nil for a nil operation")
+	}
+}
+
+func TestPruneVariables_KeepsVariablesUsedInFragments(t *testing.T) {
+	doc := parse(t, `query Q($id: ID!, $first: Int, $unused: String) { ...F }
+fragment F on Query { user(id: $id) { ...G } }
+fragment G on User { friends(first: $first) { ...G } }`)
+	pruned := astutil.PruneVariables(doc, operation(t, doc, 0))
+	expected := `query Q($id: ID!, $first: Int) {
+  ...F
+}`
+	if printed := printer.Print(pruned); printed != expected {
+		t.Fatalf("unexpected operation, expected:\n%v\ngot:\n%v", expected, printed)
+	}
+}
@@ -24,6 +24,7 @@ const (
 	StringValue  = "StringValue"
 	BooleanValue = "BooleanValue"
 	EnumValue    = "EnumValue"
+	NullValue    = "NullValue"
 	ListValue    = "ListValue"
 	ObjectValue  = "ObjectValue"
 	ObjectField  = "ObjectField"
@@ -52,7 +53,8 @@ const (
 	InputObjectDefinition = "InputObjectDefinition" // previously InputObjectTypeDefinition
 
 	// Types Extensions
-	TypeExtensionDefinition = "TypeExtensionDefinition"
+	TypeExtensionDefinition      = "TypeExtensionDefinition"
+	InterfaceExtensionDefinition = "InterfaceExtensionDefinition"
 
 	// Directive Definitions
 	DirectiveDefinition = "DirectiveDefinition"
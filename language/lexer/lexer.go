@@ -91,13 +91,26 @@ type Token struct {
 
 type Lexer func(resetPosition int) (Token, error)
 
+// Options tunes which ignored tokens the lexer tolerates. The zero value
+// matches the spec: a leading BOM, comments and commas are all skipped.
+type Options struct {
+	NoBOM      bool // reject the unicode byte order mark
+	NoComments bool // reject `#` comments
+	NoCommas   bool // reject insignificant commas
+}
+
 func Lex(s *source.Source) Lexer {
+	return LexWithOptions(s, Options{})
+}
+
+// LexWithOptions returns a Lexer for the given source that honors opts.
+func LexWithOptions(s *source.Source, opts Options) Lexer {
 	var prevPosition int
 	return func(resetPosition int) (Token, error) {
 		if resetPosition == 0 {
 			resetPosition = prevPosition
 		}
-		token, err := readToken(s, resetPosition)
+		token, err := readToken(s, resetPosition, opts)
 		if err != nil {
 			return token, err
 		}
@@ -479,10 +492,10 @@ func printCharCode(code rune) string {
 	return fmt.Sprintf(`"\\u%04X"`, code)
 }
 
-func readToken(s *source.Source, fromPosition int) (Token, error) {
+func readToken(s *source.Source, fromPosition int, opts Options) (Token, error) {
 	body := s.Body
 	bodyLength := len(body)
-	position, runePosition := positionAfterWhitespace(body, fromPosition)
+	position, runePosition := positionAfterWhitespace(body, fromPosition, opts)
 	if position >= bodyLength {
 		return makeToken(EOF, position, position, ""), nil
 	}
@@ -595,7 +608,8 @@ func runeAt(body []byte, position int) (code rune, charWidth int) {
 // or commented character, then returns the position of that character for lexing.
 // lexing.
 // Returns both byte positions and rune position
-func positionAfterWhitespace(body []byte, startPosition int) (position int, runePosition int) {
+// Ignored tokens disabled through opts are left in place to be reported by the caller.
+func positionAfterWhitespace(body []byte, startPosition int, opts Options) (position int, runePosition int) {
 	bodyLength := len(body)
 	position = startPosition
 	runePosition = startPosition
@@ -604,7 +618,7 @@ func positionAfterWhitespace(body []byte, startPosition int) (position int, rune
 			code, n := runeAt(body, position)
 
 			// Skip Ignored
-			if code == 0xFEFF || // BOM
+			if (code == 0xFEFF && !opts.NoBOM) || // BOM
 				// White Space
 				code == 0x0009 || // tab
 				code == 0x0020 || // space
@@ -612,10 +626,10 @@ func positionAfterWhitespace(body []byte, startPosition int) (position int, rune
 				code == 0x000A || // new line
 				code == 0x000D || // carriage return
 				// Comma
-				code == 0x002C {
+				(code == 0x002C && !opts.NoCommas) {
 				position += n
 				runePosition++
-			} else if code == 35 { // #
+			} else if code == 35 && !opts.NoComments { // #
 				position += n
 				runePosition++
 				for {
@@ -108,6 +108,56 @@ func TestLexer_AcceptsBOMHeader(t *testing.T) {
 	}
 }
 
+func TestLexer_RejectsDisabledIgnoredTokens(t *testing.T) {
+	tests := []struct {
+		Body     string
+		Options  Options
+		Expected string
+	}{
+		{
+			Body:     "\uFEFF foo",
+			Options:  Options{NoBOM: true},
+			Expected: "Syntax Error GraphQL (1:1) Unexpected character \"\\\\uFEFF\".\n\n1: \uFEFF foo\n   ^\n",
+		},
+		{
+			Body:    ", foo",
+			Options: Options{NoCommas: true},
+			Expected: `Syntax Error GraphQL (1:1) Unexpected character ",".
+
+1: , foo
+   ^
+`,
+		},
+		{
+			Body:    "# comment\nfoo",
+			Options: Options{NoComments: true},
+			Expected: `Syntax Error GraphQL (1:1) Unexpected character "#".
+
+1: # comment
+   ^
+2: foo
+`,
+		},
+	}
+	for _, test := range tests {
+		_, err := LexWithOptions(createSource(test.Body), test.Options)(0)
+		if err == nil {
+			t.Fatalf("unexpected nil error\nexpected:\n%v\n\ngot:\n%v", test.Expected, err)
+		}
+		if err.Error() != test.Expected {
+			t.Errorf("unexpected error.\nexpected:\n%v\n\ngot:\n%v", test.Expected, err.Error())
+		}
+		// the same body lexes fine with the default options
+		token, err := Lex(createSource(test.Body))(0)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if token.Kind != NAME || token.Value != "foo" {
+			t.Errorf("unexpected token, expected: Name \"foo\", got: %v", GetTokenDesc(token))
+		}
+	}
+}
+
 func TestLexer_SkipsWhiteSpace(t *testing.T) {
 	tests := []Test{
 		{
@@ -2,6 +2,9 @@ package parser
 
 import (
 	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
 
 	"github.com/graphql-go/graphql/gqlerrors"
 	"github.com/graphql-go/graphql/language/ast"
@@ -42,8 +45,30 @@ func init() {
 }
 
 type ParseOptions struct {
-	NoLocation bool
-	NoSource   bool
+	NoLocation   bool
+	NoSource     bool
+	LexerOptions lexer.Options
+	// SkipSelectionSets discards the selection sets of operations and
+	// fragments, keeping only their headers, for routing-only parses.
+	SkipSelectionSets bool
+	// CustomDefinitionParsers are consulted for top-level keywords the parser
+	// does not recognize, so new definition kinds can be prototyped. They start
+	// on the definition's description, if any; see Parser.ParseDescription.
+	CustomDefinitionParsers map[string]func(*Parser) (ast.Definition, error)
+	// MaxArgumentsPerField rejects fields, in selections and in type
+	// definitions, with more arguments than this. Zero means no limit.
+	MaxArgumentsPerField int
+	// CanonicalizeValues normalizes int and float literals to their shortest
+	// form, e.g. `1.10` becomes `1.1` and `1e2` becomes `100`.
+	CanonicalizeValues bool
+	// MaxErrors, when above one, recovers from syntax errors inside selection
+	// sets and argument lists and reports up to this many of them, as Errors
+	// when there is more than one.
+	MaxErrors int
+	// MaxDepth rejects documents nesting selection sets, list types, or list
+	// and object values more than this deep, counted together. Zero means no
+	// limit.
+	MaxDepth int
 }
 
 type ParseParams struct {
@@ -57,9 +82,44 @@ type Parser struct {
 	Options  ParseOptions
 	PrevEnd  int
 	Token    lexer.Token
+
+	stats    Stats
+	depth    int
+	nesting  int
+	brackets []lexer.TokenKind
+	errors   []error
+}
+
+// Stats describes a parsed document. It is gathered while parsing, so
+// selection sets skipped by SkipSelectionSets contribute no fields or depth.
+type Stats struct {
+	// Tokens is the number of lexed tokens, not counting EOF.
+	Tokens int
+	// MaxDepth is the deepest nesting of selection sets.
+	MaxDepth int
+	// Fields is the number of fields selected, not counting field definitions.
+	Fields int
+	// Definitions is the number of top-level definitions.
+	Definitions int
 }
 
 func Parse(p ParseParams) (*ast.Document, error) {
+	_, doc, err := parseWithParser(p)
+	return doc, err
+}
+
+// ParseWithStats parses like Parse and also returns Stats about the document.
+func ParseWithStats(p ParseParams) (*ast.Document, Stats, error) {
+	parser, doc, err := parseWithParser(p)
+	if err != nil {
+		return nil, Stats{}, err
+	}
+	return doc, parser.stats, nil
+}
+
+// parseWithParser parses the document in p, returning the parser alongside it
+// so callers can report what it gathered along the way.
+func parseWithParser(p ParseParams) (*Parser, *ast.Document, error) {
 	var sourceObj *source.Source
 	switch src := p.Source.(type) {
 	case *source.Source:
@@ -70,13 +130,36 @@ func Parse(p ParseParams) (*ast.Document, error) {
 	}
 	parser, err := makeParser(sourceObj, p.Options)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	doc, err := parseDocument(parser)
+	if err = collectErrors(parser, err); err != nil {
+		return nil, nil, err
+	}
+	return parser, doc, nil
+}
+
+// ParseVariableDefinitions parses a standalone variable definitions section,
+// e.g. `($id: ID!, $first: Int = 10)`, as found in an operation header.
+func ParseVariableDefinitions(src string) ([]*ast.VariableDefinition, error) {
+	sourceObj := source.NewSource(&source.Source{Body: []byte(src)})
+	parser, err := makeParser(sourceObj, ParseOptions{})
+	if err != nil {
+		return nil, err
+	}
+	// unlike in an operation header, the parentheses are not optional
+	if !peek(parser, lexer.PAREN_L) {
+		_, err := expect(parser, lexer.PAREN_L)
+		return nil, err
+	}
+	variableDefinitions, err := parseVariableDefinitions(parser)
 	if err != nil {
 		return nil, err
 	}
-	return doc, nil
+	if _, err := expect(parser, lexer.EOF); err != nil {
+		return nil, err
+	}
+	return variableDefinitions, nil
 }
 
 // TODO: test and expose parseValue as a public
@@ -114,7 +197,7 @@ func parseName(parser *Parser) (*ast.Name, error) {
 }
 
 func makeParser(s *source.Source, opts ParseOptions) (*Parser, error) {
-	lexToken := lexer.Lex(s)
+	lexToken := lexer.LexWithOptions(s, opts.LexerOptions)
 	token, err := lexToken(0)
 	if err != nil {
 		return &Parser{}, err
@@ -154,6 +237,7 @@ func parseDocument(parser *Parser) (*ast.Document, error) {
 			return nil, err
 		}
 		nodes = append(nodes, node)
+		parser.stats.Definitions++
 	}
 	return ast.NewDocument(&ast.Document{
 		Loc:         loc(parser, start),
@@ -179,7 +263,7 @@ func parseOperationDefinition(parser *Parser) (ast.Node, error) {
 	)
 	start := parser.Token.Start
 	if peek(parser, lexer.BRACE_L) {
-		selectionSet, err := parseSelectionSet(parser)
+		selectionSet, err := parseDefinitionSelectionSet(parser)
 		if err != nil {
 			return nil, err
 		}
@@ -205,7 +289,7 @@ func parseOperationDefinition(parser *Parser) (ast.Node, error) {
 	if directives, err = parseDirectives(parser); err != nil {
 		return nil, err
 	}
-	if selectionSet, err = parseSelectionSet(parser); err != nil {
+	if selectionSet, err = parseDefinitionSelectionSet(parser); err != nil {
 		return nil, err
 	}
 	return ast.NewOperationDefinition(&ast.OperationDefinition{
@@ -246,6 +330,9 @@ func parseVariableDefinitions(parser *Parser) ([]*ast.VariableDefinition, error)
 	if !peek(parser, lexer.PAREN_L) {
 		return variableDefinitions, nil
 	}
+	if err := expectNonEmpty(parser, "variable definition"); err != nil {
+		return variableDefinitions, err
+	}
 	if vdefs, err := reverse(parser,
 		lexer.PAREN_L, parseVariableDefinition, lexer.PAREN_R,
 		true,
@@ -320,15 +407,27 @@ func parseVariable(parser *Parser) (*ast.Variable, error) {
  */
 func parseSelectionSet(parser *Parser) (*ast.SelectionSet, error) {
 	start := parser.Token.Start
+	parser.depth++
+	defer func() { parser.depth-- }()
+	if parser.depth > parser.stats.MaxDepth {
+		parser.stats.MaxDepth = parser.depth
+	}
+	err := enterNesting(parser, start)
+	defer leaveNesting(parser)
+	if err != nil {
+		return nil, err
+	}
 	selections := []ast.Selection{}
 	if iSelections, err := reverse(parser,
-		lexer.BRACE_L, parseSelection, lexer.BRACE_R,
+		lexer.BRACE_L, recoverable(parseSelection, lexer.BRACE_R), lexer.BRACE_R,
 		true,
 	); err != nil {
 		return nil, err
 	} else {
 		for _, iSelection := range iSelections {
-			selections = append(selections, iSelection.(ast.Selection))
+			if iSelection != nil {
+				selections = append(selections, iSelection.(ast.Selection))
+			}
 		}
 	}
 
@@ -338,6 +437,35 @@ func parseSelectionSet(parser *Parser) (*ast.SelectionSet, error) {
 	}), nil
 }
 
+// parseDefinitionSelectionSet parses the selection set of an operation or
+// fragment definition, or skips over it when SkipSelectionSets is set.
+func parseDefinitionSelectionSet(parser *Parser) (*ast.SelectionSet, error) {
+	if !parser.Options.SkipSelectionSets {
+		return parseSelectionSet(parser)
+	}
+	start := parser.Token.Start
+	if _, err := expect(parser, lexer.BRACE_L); err != nil {
+		return nil, err
+	}
+	for depth := 1; depth > 0; {
+		switch parser.Token.Kind {
+		case lexer.EOF:
+			return nil, unexpected(parser, lexer.Token{})
+		case lexer.BRACE_L:
+			depth++
+		case lexer.BRACE_R:
+			depth--
+		}
+		if err := advance(parser); err != nil {
+			return nil, err
+		}
+	}
+	return ast.NewSelectionSet(&ast.SelectionSet{
+		Selections: []ast.Selection{},
+		Loc:        loc(parser, start),
+	}), nil
+}
+
 /**
  * Selection :
  *   - Field
@@ -365,6 +493,7 @@ func parseField(parser *Parser) (*ast.Field, error) {
 		err        error
 	)
 	start := parser.Token.Start
+	parser.stats.Fields++
 	if name, err = parseName(parser); err != nil {
 		return nil, err
 	}
@@ -379,6 +508,9 @@ func parseField(parser *Parser) (*ast.Field, error) {
 	if arguments, err = parseArguments(parser); err != nil {
 		return nil, err
 	}
+	if err = checkArgumentCount(parser, start, name, len(arguments)); err != nil {
+		return nil, err
+	}
 	if directives, err = parseDirectives(parser); err != nil {
 		return nil, err
 	}
@@ -404,14 +536,19 @@ func parseField(parser *Parser) (*ast.Field, error) {
 func parseArguments(parser *Parser) ([]*ast.Argument, error) {
 	arguments := []*ast.Argument{}
 	if peek(parser, lexer.PAREN_L) {
+		if err := expectNonEmpty(parser, "at least one argument"); err != nil {
+			return arguments, err
+		}
 		if iArguments, err := reverse(parser,
-			lexer.PAREN_L, parseArgument, lexer.PAREN_R,
+			lexer.PAREN_L, recoverable(parseArgument, lexer.PAREN_R), lexer.PAREN_R,
 			true,
 		); err != nil {
 			return arguments, err
 		} else {
 			for _, iArgument := range iArguments {
-				arguments = append(arguments, iArgument.(*ast.Argument))
+				if iArgument != nil {
+					arguments = append(arguments, iArgument.(*ast.Argument))
+				}
 			}
 		}
 	}
@@ -532,7 +669,7 @@ func parseFragmentDefinition(parser *Parser) (ast.Node, error) {
 	if err != nil {
 		return nil, err
 	}
-	selectionSet, err := parseSelectionSet(parser)
+	selectionSet, err := parseDefinitionSelectionSet(parser)
 	if err != nil {
 		return nil, err
 	}
@@ -564,12 +701,15 @@ func parseFragmentName(parser *Parser) (*ast.Name, error) {
  *   - FloatValue
  *   - StringValue
  *   - BooleanValue
+ *   - NullValue
  *   - EnumValue
  *   - ListValue[?Const]
  *   - ObjectValue[?Const]
  *
  * BooleanValue : one of `true` `false`
  *
+ * NullValue : `null`
+ *
  * EnumValue : Name but not `true`, `false` or `null`
  */
 func parseValueLiteral(parser *Parser, isConst bool) (ast.Value, error) {
@@ -583,16 +723,24 @@ func parseValueLiteral(parser *Parser, isConst bool) (ast.Value, error) {
 		if err := advance(parser); err != nil {
 			return nil, err
 		}
+		value := token.Value
+		if parser.Options.CanonicalizeValues {
+			value = canonicalInt(value)
+		}
 		return ast.NewIntValue(&ast.IntValue{
-			Value: token.Value,
+			Value: value,
 			Loc:   loc(parser, token.Start),
 		}), nil
 	case lexer.FLOAT:
 		if err := advance(parser); err != nil {
 			return nil, err
 		}
+		value := token.Value
+		if parser.Options.CanonicalizeValues {
+			value = canonicalFloat(value)
+		}
 		return ast.NewFloatValue(&ast.FloatValue{
-			Value: token.Value,
+			Value: value,
 			Loc:   loc(parser, token.Start),
 		}), nil
 	case lexer.BLOCK_STRING, lexer.STRING:
@@ -610,24 +758,63 @@ func parseValueLiteral(parser *Parser, isConst bool) (ast.Value, error) {
 				Value: value,
 				Loc:   loc(parser, token.Start),
 			}), nil
-		} else if token.Value != "null" {
+		} else if token.Value == "null" {
 			if err := advance(parser); err != nil {
 				return nil, err
 			}
-			return ast.NewEnumValue(&ast.EnumValue{
-				Value: token.Value,
-				Loc:   loc(parser, token.Start),
+			return ast.NewNullValue(&ast.NullValue{
+				Loc: loc(parser, token.Start),
 			}), nil
 		}
+		if err := advance(parser); err != nil {
+			return nil, err
+		}
+		return ast.NewEnumValue(&ast.EnumValue{
+			Value: token.Value,
+			Loc:   loc(parser, token.Start),
+		}), nil
 	case lexer.DOLLAR:
 		if !isConst {
 			return parseVariable(parser)
 		}
+		return nil, gqlerrors.NewSyntaxError(parser.Source, token.Start,
+			"Unexpected $, variables are not allowed in constant positions")
 	}
 
 	return nil, unexpected(parser, lexer.Token{})
 }
 
+// canonicalInt drops the sign of a negative zero; the lexer already rejects
+// leading zeros. Integers of any size are kept exact.
+func canonicalInt(value string) string {
+	if i, ok := new(big.Int).SetString(value, 10); ok {
+		return i.String()
+	}
+	return value
+}
+
+// canonicalFloat formats a float literal in the shortest form that parses
+// back to the same float64. Literals out of range are kept as written.
+func canonicalFloat(value string) string {
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return value
+	}
+	formatted := strconv.FormatFloat(f, 'g', -1, 64)
+	e := strings.IndexByte(formatted, 'e')
+	if e < 0 {
+		return formatted
+	}
+	mantissa, exponent := formatted[:e], formatted[e+1:]
+	// FormatFloat pads exponents to two digits and signs them, e.g. `1.5e-07`
+	// or `6e+23`; neither is needed to read the literal back.
+	sign := ""
+	if exponent[0] == '-' {
+		sign = "-"
+	}
+	return mantissa + "e" + sign + strings.TrimLeft(exponent[1:], "0")
+}
+
 func parseConstValue(parser *Parser) (interface{}, error) {
 	value, err := parseValueLiteral(parser, true)
 	if err != nil {
@@ -647,6 +834,11 @@ func parseValueValue(parser *Parser) (interface{}, error) {
  */
 func parseList(parser *Parser, isConst bool) (*ast.ListValue, error) {
 	start := parser.Token.Start
+	err := enterNesting(parser, start)
+	defer leaveNesting(parser)
+	if err != nil {
+		return nil, err
+	}
 	var item parseFn = parseValueValue
 	if isConst {
 		item = parseConstValue
@@ -675,6 +867,11 @@ func parseList(parser *Parser, isConst bool) (*ast.ListValue, error) {
  */
 func parseObject(parser *Parser, isConst bool) (*ast.ObjectValue, error) {
 	start := parser.Token.Start
+	err := enterNesting(parser, start)
+	defer leaveNesting(parser)
+	if err != nil {
+		return nil, err
+	}
 	if _, err := expect(parser, lexer.BRACE_L); err != nil {
 		return nil, err
 	}
@@ -779,15 +976,18 @@ func parseType(parser *Parser) (ttype ast.Type, err error) {
 	// [ String! ]!
 	switch token.Kind {
 	case lexer.BRACKET_L:
+		err = enterNesting(parser, token.Start)
+		defer leaveNesting(parser)
+		if err != nil {
+			return nil, err
+		}
 		if err = advance(parser); err != nil {
 			return nil, err
 		}
 		if ttype, err = parseType(parser); err != nil {
 			return nil, err
 		}
-		fallthrough
-	case lexer.BRACKET_R:
-		if err = advance(parser); err != nil {
+		if _, err = expect(parser, lexer.BRACKET_R); err != nil {
 			return nil, err
 		}
 		ttype = ast.NewList(&ast.List{
@@ -798,6 +998,8 @@ func parseType(parser *Parser) (ttype ast.Type, err error) {
 		if ttype, err = parseNamed(parser); err != nil {
 			return nil, err
 		}
+	default:
+		return nil, unexpected(parser, lexer.Token{})
 	}
 
 	// BANG must be executed
@@ -862,6 +1064,9 @@ func parseTypeSystemDefinition(parser *Parser) (ast.Node, error) {
 	}
 	var ok bool
 	if item, ok = tokenDefinitionFn[keywordToken.Value]; !ok {
+		if custom, ok := parser.Options.CustomDefinitionParsers[keywordToken.Value]; ok {
+			return custom(parser)
+		}
 		return nil, unexpected(parser, keywordToken)
 	}
 	return item(parser)
@@ -1016,17 +1221,19 @@ func parseImplementsInterfaces(parser *Parser) ([]*ast.Named, error) {
 			return nil, err
 		}
 		// optional leading ampersand
-		skip(parser, lexer.AMP)
+		if _, err := skip(parser, lexer.AMP); err != nil {
+			return types, err
+		}
 		for {
 			ttype, err := parseNamed(parser)
 			if err != nil {
 				return types, err
 			}
 			types = append(types, ttype)
-			if skipped, err := skip(parser, lexer.AMP); !skipped {
-				break
-			} else if err != nil {
+			if skipped, err := skip(parser, lexer.AMP); err != nil {
 				return types, err
+			} else if !skipped {
+				break
 			}
 		}
 	}
@@ -1050,6 +1257,9 @@ func parseFieldDefinition(parser *Parser) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err = checkArgumentCount(parser, start, name, len(args)); err != nil {
+		return nil, err
+	}
 	_, err = expect(parser, lexer.COLON)
 	if err != nil {
 		return nil, err
@@ -1230,11 +1440,15 @@ func parseUnionTypeDefinition(parser *Parser) (ast.Node, error) {
 
 /**
  * UnionMembers :
- *   - NamedType
+ *   - `|`? NamedType
  *   - UnionMembers | NamedType
  */
 func parseUnionMembers(parser *Parser) ([]*ast.Named, error) {
 	members := []*ast.Named{}
+	// optional leading pipe
+	if _, err := skip(parser, lexer.PIPE); err != nil {
+		return members, err
+	}
 	for {
 		member, err := parseNamed(parser)
 		if err != nil {
@@ -1296,7 +1510,7 @@ func parseEnumTypeDefinition(parser *Parser) (ast.Node, error) {
 /**
  * EnumValueDefinition : Description? EnumValue Directives?
  *
- * EnumValue : Name
+ * EnumValue : Name but not `true`, `false` or `null`
  */
 func parseEnumValueDefinition(parser *Parser) (interface{}, error) {
 	start := parser.Token.Start
@@ -1304,7 +1518,7 @@ func parseEnumValueDefinition(parser *Parser) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
-	name, err := parseName(parser)
+	name, err := parseEnumValueName(parser)
 	if err != nil {
 		return nil, err
 	}
@@ -1320,6 +1534,14 @@ func parseEnumValueDefinition(parser *Parser) (interface{}, error) {
 	}), nil
 }
 
+func parseEnumValueName(parser *Parser) (*ast.Name, error) {
+	switch parser.Token.Value {
+	case "true", "false", "null":
+		return nil, unexpected(parser, lexer.Token{})
+	}
+	return parseName(parser)
+}
+
 /**
  * InputObjectTypeDefinition :
  *   - Description? input Name Directives? { InputValueDefinition+ }
@@ -1365,7 +1587,9 @@ func parseInputObjectTypeDefinition(parser *Parser) (ast.Node, error) {
 }
 
 /**
- * TypeExtensionDefinition : extend ObjectTypeDefinition
+ * TypeExtensionDefinition :
+ *   - extend ObjectTypeDefinition
+ *   - extend InterfaceTypeDefinition
  */
 func parseTypeExtensionDefinition(parser *Parser) (ast.Node, error) {
 	start := parser.Token.Start
@@ -1374,6 +1598,16 @@ func parseTypeExtensionDefinition(parser *Parser) (ast.Node, error) {
 		return nil, err
 	}
 
+	if parser.Token.Kind == lexer.NAME && parser.Token.Value == lexer.INTERFACE {
+		definition, err := parseInterfaceTypeDefinition(parser)
+		if err != nil {
+			return nil, err
+		}
+		return ast.NewInterfaceExtensionDefinition(&ast.InterfaceExtensionDefinition{
+			Loc:        loc(parser, start),
+			Definition: definition.(*ast.InterfaceDefinition),
+		}), nil
+	}
 	definition, err := parseObjectTypeDefinition(parser)
 	if err != nil {
 		return nil, err
@@ -1386,7 +1620,7 @@ func parseTypeExtensionDefinition(parser *Parser) (ast.Node, error) {
 
 /**
  * DirectiveDefinition :
- *   - directive @ Name ArgumentsDefinition? on DirectiveLocations
+ *   - directive @ Name ArgumentsDefinition? repeatable? on DirectiveLocations
  */
 func parseDirectiveDefinition(parser *Parser) (ast.Node, error) {
 	var (
@@ -1394,6 +1628,7 @@ func parseDirectiveDefinition(parser *Parser) (ast.Node, error) {
 		description *ast.StringValue
 		name        *ast.Name
 		args        []*ast.InputValueDefinition
+		repeatable  bool
 		locations   []*ast.Name
 	)
 	start := parser.Token.Start
@@ -1412,31 +1647,62 @@ func parseDirectiveDefinition(parser *Parser) (ast.Node, error) {
 	if args, err = parseArgumentDefs(parser); err != nil {
 		return nil, err
 	}
+	if repeatable, err = skipKeyWord(parser, "repeatable"); err != nil {
+		return nil, err
+	}
 	if _, err = expectKeyWord(parser, "on"); err != nil {
 		return nil, err
 	}
 	if locations, err = parseDirectiveLocations(parser); err != nil {
 		return nil, err
 	}
+	if peek(parser, lexer.NAME) && parser.Token.Value == "repeatable" {
+		descp := `Unexpected Name "repeatable", it must come before "on"`
+		return nil, gqlerrors.NewSyntaxError(parser.Source, parser.Token.Start, descp)
+	}
 
 	return ast.NewDirectiveDefinition(&ast.DirectiveDefinition{
 		Loc:         loc(parser, start),
 		Name:        name,
 		Description: description,
 		Arguments:   args,
+		Repeatable:  repeatable,
 		Locations:   locations,
 	}), nil
 }
 
+// directiveLocations lists the executable and type system directive locations.
+var directiveLocations = map[string]bool{
+	"QUERY":                  true,
+	"MUTATION":               true,
+	"SUBSCRIPTION":           true,
+	"FIELD":                  true,
+	"FRAGMENT_DEFINITION":    true,
+	"FRAGMENT_SPREAD":        true,
+	"INLINE_FRAGMENT":        true,
+	"VARIABLE_DEFINITION":    true,
+	"SCHEMA":                 true,
+	"SCALAR":                 true,
+	"OBJECT":                 true,
+	"FIELD_DEFINITION":       true,
+	"ARGUMENT_DEFINITION":    true,
+	"INTERFACE":              true,
+	"UNION":                  true,
+	"ENUM":                   true,
+	"ENUM_VALUE":             true,
+	"INPUT_OBJECT":           true,
+	"INPUT_FIELD_DEFINITION": true,
+}
+
 /**
  * DirectiveLocations :
- *   - Name
- *   - DirectiveLocations | Name
+ *   - DirectiveLocation
+ *   - DirectiveLocations | DirectiveLocation
  */
 func parseDirectiveLocations(parser *Parser) ([]*ast.Name, error) {
 	locations := []*ast.Name{}
 	for {
-		if name, err := parseName(parser); err != nil {
+		if name, err := parseDirectiveLocation(parser); err != nil {
 			return locations, err
 		} else {
 			locations = append(locations, name)
@@ -1451,6 +1717,16 @@ func parseDirectiveLocations(parser *Parser) ([]*ast.Name, error) {
 	return locations, nil
 }
 
+/**
+ * DirectiveLocation : Name but one of the known directive locations
+ */
+func parseDirectiveLocation(parser *Parser) (*ast.Name, error) {
+	if parser.Token.Kind == lexer.NAME && !directiveLocations[parser.Token.Value] {
+		return nil, unexpected(parser, lexer.Token{})
+	}
+	return parseName(parser)
+}
+
 func parseStringLiteral(parser *Parser) (*ast.StringValue, error) {
 	token := parser.Token
 	if err := advance(parser); err != nil {
@@ -1474,6 +1750,47 @@ func parseDescription(parser *Parser) (*ast.StringValue, error) {
 
 /* Core parsing utility functions */
 
+// The exported helpers below give custom definition parsers access to the
+// same primitives the built-in parsers use.
+
+// Peek determines if the next token is of the given kind.
+func (parser *Parser) Peek(kind lexer.TokenKind) bool {
+	return peek(parser, kind)
+}
+
+// Skip advances past the next token if it is of the given kind.
+func (parser *Parser) Skip(kind lexer.TokenKind) (bool, error) {
+	return skip(parser, kind)
+}
+
+// Expect advances past the next token, which must be of the given kind.
+func (parser *Parser) Expect(kind lexer.TokenKind) (lexer.Token, error) {
+	return expect(parser, kind)
+}
+
+// ExpectKeyWord advances past the next token, which must be the given keyword.
+func (parser *Parser) ExpectKeyWord(value string) (lexer.Token, error) {
+	return expectKeyWord(parser, value)
+}
+
+// ParseName parses the next token as a Name node.
+func (parser *Parser) ParseName() (*ast.Name, error) {
+	return parseName(parser)
+}
+
+// ParseDescription parses the description preceding a definition, returning
+// nil if there is none. Custom definition parsers are handed the parser
+// positioned on the description, if any, rather than on their keyword.
+func (parser *Parser) ParseDescription() (*ast.StringValue, error) {
+	return parseDescription(parser)
+}
+
+// Loc returns the location from start to the end of the last consumed token,
+// honoring the NoLocation and NoSource options.
+func (parser *Parser) Loc(start int) *ast.Location {
+	return loc(parser, start)
+}
+
 // Returns a location object, used to identify the place in
 // the source that created a given parsed object.
 func loc(parser *Parser, start int) *ast.Location {
@@ -1495,6 +1812,14 @@ func loc(parser *Parser, start int) *ast.Location {
 
 // Moves the internal parser object to the next lexed token.
 func advance(parser *Parser) error {
+	if parser.Token.Kind != lexer.EOF {
+		parser.stats.Tokens++
+	}
+	if _, ok := closingBrackets[parser.Token.Kind]; ok {
+		parser.brackets = append(parser.brackets, parser.Token.Kind)
+	} else if isClosingBracket(parser.Token.Kind) && len(parser.brackets) > 0 {
+		parser.brackets = parser.brackets[:len(parser.brackets)-1]
+	}
 	parser.PrevEnd = parser.Token.End
 	token, err := parser.LexToken(parser.PrevEnd)
 	if err != nil {
@@ -1528,6 +1853,15 @@ func skip(parser *Parser, Kind lexer.TokenKind) (bool, error) {
 	return false, nil
 }
 
+// If the next token is a keyword with the given value, return true after
+// advancing the parser. Otherwise, do not change the parser state and return false.
+func skipKeyWord(parser *Parser, value string) (bool, error) {
+	if parser.Token.Kind == lexer.NAME && parser.Token.Value == value {
+		return true, advance(parser)
+	}
+	return false, nil
+}
+
 // If the next token is of the given kind, return that token after advancing
 // the parser. Otherwise, do not change the parser state and return error.
 func expect(parser *Parser, kind lexer.TokenKind) (lexer.Token, error) {
@@ -1561,11 +1895,157 @@ func unexpected(parser *Parser, atToken lexer.Token) error {
 	return gqlerrors.NewSyntaxError(parser.Source, token.Start, description)
 }
 
+// expectNonEmpty reports a syntax error when the token following the current
+// opening token closes the list straight away, e.g. `field()`, saying what was
+// expected instead.
+func expectNonEmpty(parser *Parser, expected string) error {
+	token, err := lookahead(parser)
+	if err != nil {
+		return err
+	}
+	if token.Kind != lexer.PAREN_R {
+		return nil
+	}
+	description := fmt.Sprintf("Expected %s, found '%s'", expected, lexer.GetTokenDesc(token))
+	return gqlerrors.NewSyntaxError(parser.Source, token.Start, description)
+}
+
+// checkArgumentCount enforces ParseOptions.MaxArgumentsPerField for the field
+// starting at start.
+func checkArgumentCount(parser *Parser, start int, name *ast.Name, count int) error {
+	max := parser.Options.MaxArgumentsPerField
+	if max <= 0 || count <= max {
+		return nil
+	}
+	description := fmt.Sprintf("Field \"%v\" has %d arguments, more than the maximum of %d", name.Value, count, max)
+	return gqlerrors.NewSyntaxError(parser.Source, start, description)
+}
+
+// enterNesting enters a selection set, list type or list or object value,
+// enforcing ParseOptions.MaxDepth. It must be paired with a deferred
+// leaveNesting even when it fails, so the depth unwinds on error paths too.
+func enterNesting(parser *Parser, start int) error {
+	parser.nesting++
+	max := parser.Options.MaxDepth
+	if max <= 0 || parser.nesting <= max {
+		return nil
+	}
+	description := fmt.Sprintf("Nesting exceeds the maximum depth of %d", max)
+	return gqlerrors.NewSyntaxError(parser.Source, start, description)
+}
+
+func leaveNesting(parser *Parser) {
+	parser.nesting--
+}
+
 func unexpectedEmpty(parser *Parser, beginLoc int, openKind, closeKind lexer.TokenKind) error {
 	description := fmt.Sprintf("Unexpected empty IN %s%s", openKind, closeKind)
 	return gqlerrors.NewSyntaxError(parser.Source, beginLoc, description)
 }
 
+// Errors lists the syntax errors found in a document parsed with MaxErrors,
+// in source order.
+type Errors []error
+
+func (errs Errors) Error() string {
+	messages := []string{}
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "\n")
+}
+
+// recoverable wraps the parseFn of a list item so that, when MaxErrors allows
+// it, a syntax error is recorded and the parser skips ahead to the closeKind
+// token of the list instead of failing. Brackets the item opened before the
+// error are closed first, so stray closing brackets inside them are skipped
+// rather than mistaken for the end of the list. Recovered items are returned
+// as nil.
+func recoverable(parseFn parseFn, closeKind lexer.TokenKind) parseFn {
+	return func(parser *Parser) (interface{}, error) {
+		base := len(parser.brackets)
+		item, err := parseFn(parser)
+		if err == nil || parser.Options.MaxErrors <= 1 || len(parser.errors)+1 >= parser.Options.MaxErrors {
+			return item, err
+		}
+		if _, ok := err.(*gqlerrors.Error); !ok || isRecorded(parser, err) {
+			return item, err
+		}
+		parser.errors = append(parser.errors, err)
+		open := []lexer.TokenKind{}
+		if base < len(parser.brackets) {
+			open = append(open, parser.brackets[base:]...)
+		}
+		for {
+			kind := parser.Token.Kind
+			if _, ok := closingBrackets[kind]; ok {
+				open = append(open, kind)
+			} else if isClosingBracket(kind) {
+				if len(open) == 0 {
+					if kind != closeKind {
+						return nil, parser.errors[len(parser.errors)-1]
+					}
+					if base < len(parser.brackets) {
+						parser.brackets = parser.brackets[:base]
+					}
+					return nil, nil
+				}
+				if closingBrackets[open[len(open)-1]] == kind {
+					open = open[:len(open)-1]
+				}
+			} else if kind == lexer.EOF {
+				return nil, parser.errors[len(parser.errors)-1]
+			}
+			if err := advance(parser); err != nil {
+				if !isRecorded(parser, err) {
+					parser.errors = append(parser.errors, err)
+				}
+				return nil, parser.errors[len(parser.errors)-1]
+			}
+		}
+	}
+}
+
+// closingBrackets maps each opening bracket to the bracket closing it.
+var closingBrackets = map[lexer.TokenKind]lexer.TokenKind{
+	lexer.BRACE_L:   lexer.BRACE_R,
+	lexer.PAREN_L:   lexer.PAREN_R,
+	lexer.BRACKET_L: lexer.BRACKET_R,
+}
+
+func isClosingBracket(kind lexer.TokenKind) bool {
+	return kind == lexer.BRACE_R || kind == lexer.PAREN_R || kind == lexer.BRACKET_R
+}
+
+// isRecorded reports whether err is the last error recovered from, e.g. when
+// an inner list gave up recovering and the error reaches an outer list.
+func isRecorded(parser *Parser, err error) bool {
+	if len(parser.errors) == 0 {
+		return false
+	}
+	last := parser.errors[len(parser.errors)-1]
+	return last == err || last.Error() == err.Error()
+}
+
+// collectErrors merges the error a parse ended with into the errors recovered
+// from along the way, when MaxErrors is set.
+func collectErrors(parser *Parser, err error) error {
+	if parser.Options.MaxErrors <= 1 {
+		return err
+	}
+	errs := Errors(parser.errors)
+	if err != nil && !isRecorded(parser, err) {
+		errs = append(errs, err)
+	}
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	}
+	return errs
+}
+
 //  Returns list of parse nodes, determined by
 // the parseFn. This list begins with a lex token of openKind
 // and ends with a lex token of closeKind. Advances the parser
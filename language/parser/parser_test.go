@@ -9,6 +9,8 @@ import (
 
 	"github.com/graphql-go/graphql/gqlerrors"
 	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/kinds"
+	"github.com/graphql-go/graphql/language/lexer"
 	"github.com/graphql-go/graphql/language/location"
 	"github.com/graphql-go/graphql/language/printer"
 	"github.com/graphql-go/graphql/language/source"
@@ -80,6 +82,384 @@ func TestAcceptsOptionToNotIncludeSource(t *testing.T) {
 	}
 }
 
+func TestAcceptsLexerOptions(t *testing.T) {
+	body := "{ a, b }"
+	if _, err := Parse(ParseParams{Source: body}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err := Parse(ParseParams{
+		Source: body,
+		Options: ParseOptions{
+			LexerOptions: lexer.Options{NoCommas: true},
+		},
+	})
+	checkErrorMessage(t, err, `Syntax Error GraphQL (1:4) Unexpected character ",".`)
+
+	_, err = Parse(ParseParams{
+		Source: "# leading comment\n{ a }",
+		Options: ParseOptions{
+			LexerOptions: lexer.Options{NoComments: true},
+		},
+	})
+	checkErrorMessage(t, err, `Syntax Error GraphQL (1:1) Unexpected character "#".`)
+}
+
+func TestAcceptsCustomDefinitionParsers(t *testing.T) {
+	body := "widget Gear\ntype Query { a: String }"
+	_, err := Parse(ParseParams{Source: body})
+	checkErrorMessage(t, err, `Syntax Error GraphQL (1:1) Unexpected Name "widget"`)
+
+	parseWidget := func(parser *Parser) (ast.Definition, error) {
+		start := parser.Token.Start
+		description, err := parser.ParseDescription()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := parser.ExpectKeyWord("widget"); err != nil {
+			return nil, err
+		}
+		name, err := parser.ParseName()
+		if err != nil {
+			return nil, err
+		}
+		return ast.NewScalarDefinition(&ast.ScalarDefinition{
+			Description: description,
+			Name:        name,
+			Directives:  []*ast.Directive{},
+			Loc:         parser.Loc(start),
+		}), nil
+	}
+	opts := ParseOptions{
+		NoSource: true,
+		CustomDefinitionParsers: map[string]func(*Parser) (ast.Definition, error){
+			"widget": parseWidget,
+		},
+	}
+	doc, err := Parse(ParseParams{Source: body, Options: opts})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(doc.Definitions) != 2 {
+		t.Fatalf("expected two definitions, got: %v", len(doc.Definitions))
+	}
+	expected := ast.NewScalarDefinition(&ast.ScalarDefinition{
+		Name: ast.NewName(&ast.Name{
+			Value: "Gear",
+			Loc:   &ast.Location{Start: 7, End: 11},
+		}),
+		Directives: []*ast.Directive{},
+		Loc:        &ast.Location{Start: 0, End: 11},
+	})
+	if !reflect.DeepEqual(doc.Definitions[0], expected) {
+		t.Fatalf("unexpected definition, expected: %v, got: %v", expected, doc.Definitions[0])
+	}
+	if _, ok := doc.Definitions[1].(*ast.ObjectDefinition); !ok {
+		t.Fatalf("expected an object definition, got: %T", doc.Definitions[1])
+	}
+
+	doc, err = Parse(ParseParams{Source: `"doc" widget Gear`, Options: opts})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected = ast.NewScalarDefinition(&ast.ScalarDefinition{
+		Description: ast.NewStringValue(&ast.StringValue{
+			Value: "doc",
+			Loc:   &ast.Location{Start: 0, End: 5},
+		}),
+		Name: ast.NewName(&ast.Name{
+			Value: "Gear",
+			Loc:   &ast.Location{Start: 13, End: 17},
+		}),
+		Directives: []*ast.Directive{},
+		Loc:        &ast.Location{Start: 0, End: 17},
+	})
+	if !reflect.DeepEqual(doc.Definitions[0], expected) {
+		t.Fatalf("unexpected definition, expected: %v, got: %v", expected, doc.Definitions[0])
+	}
+}
+
+func TestAcceptsOptionToSkipSelectionSets(t *testing.T) {
+	body := `query Q($id: ID!) @persisted(hash: "abc") {
+  user(id: $id) { friends { name } }
+}
+fragment F on User { name }`
+	doc, err := Parse(ParseParams{
+		Source: body,
+		Options: ParseOptions{
+			NoSource:          true,
+			SkipSelectionSets: true,
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	op := doc.Definitions[0].(*ast.OperationDefinition)
+	if op.Operation != "query" || op.Name.Value != "Q" {
+		t.Fatalf("unexpected operation header: %v %v", op.Operation, op.Name)
+	}
+	if len(op.VariableDefinitions) != 1 || op.VariableDefinitions[0].Variable.Name.Value != "id" {
+		t.Fatalf("unexpected variable definitions: %v", op.VariableDefinitions)
+	}
+	if len(op.Directives) != 1 || op.Directives[0].Name.Value != "persisted" {
+		t.Fatalf("unexpected directives: %v", op.Directives)
+	}
+	if len(op.SelectionSet.Selections) != 0 {
+		t.Fatalf("expected the selection set to be skipped, got: %v", op.SelectionSet.Selections)
+	}
+	if !reflect.DeepEqual(op.SelectionSet.Loc, testLoc(42, 82)) {
+		t.Fatalf("unexpected selection set location: %v", op.SelectionSet.Loc)
+	}
+	fragment := doc.Definitions[1].(*ast.FragmentDefinition)
+	if fragment.Name.Value != "F" || len(fragment.SelectionSet.Selections) != 0 {
+		t.Fatalf("unexpected fragment: %v", fragment)
+	}
+
+	_, err = Parse(ParseParams{
+		Source:  "{ a { b }",
+		Options: ParseOptions{SkipSelectionSets: true},
+	})
+	checkErrorMessage(t, err, `Syntax Error GraphQL (1:10) Unexpected EOF`)
+}
+
+func TestAcceptsMaxArgumentsPerFieldOption(t *testing.T) {
+	opts := ParseOptions{MaxArgumentsPerField: 2}
+	for _, body := range []string{
+		`{ user(id: 1, name: "a") { friends(first: 1) } }`,
+		`type Query { user(id: ID, name: String): User }`,
+	} {
+		if _, err := Parse(ParseParams{Source: body, Options: opts}); err != nil {
+			t.Fatalf("unexpected error at the limit: %v", err)
+		}
+	}
+
+	_, err := Parse(ParseParams{
+		Source:  `{ user { friends(first: 1, after: "x", order: ASC) } }`,
+		Options: opts,
+	})
+	checkErrorMessage(t, err, `Syntax Error GraphQL (1:10) Field "friends" has 3 arguments, more than the maximum of 2`)
+
+	_, err = Parse(ParseParams{
+		Source:  `type Query { user(id: ID, name: String, email: String): User }`,
+		Options: opts,
+	})
+	checkErrorMessage(t, err, `Syntax Error GraphQL (1:14) Field "user" has 3 arguments, more than the maximum of 2`)
+}
+
+func TestParseVariableDefinitions(t *testing.T) {
+	defs, err := ParseVariableDefinitions(`($id: ID!, $first: Int = 10, $tags: [String])`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []struct {
+		name  string
+		ttype string
+	}{
+		{"id", "ID!"},
+		{"first", "Int"},
+		{"tags", "[String]"},
+	}
+	if len(defs) != len(expected) {
+		t.Fatalf("expected %d variable definitions, got: %v", len(expected), defs)
+	}
+	for i, def := range defs {
+		if def.Variable.Name.Value != expected[i].name || ast.TypeString(def.Type) != expected[i].ttype {
+			t.Fatalf("expected $%v: %v at index %d, got: $%v: %v", expected[i].name, expected[i].ttype, i,
+				def.Variable.Name.Value, ast.TypeString(def.Type))
+		}
+	}
+	if defs[1].DefaultValue == nil || defs[1].DefaultValue.GetValue() != "10" {
+		t.Fatalf("unexpected default value: %v", defs[1].DefaultValue)
+	}
+
+	_, err = ParseVariableDefinitions(`($id: ID!) { field }`)
+	checkErrorMessage(t, err, `Syntax Error GraphQL (1:12) Expected EOF, found {`)
+
+	_, err = ParseVariableDefinitions(``)
+	checkErrorMessage(t, err, `Syntax Error GraphQL (1:1) Expected (, found EOF`)
+	_, err = ParseVariableDefinitions(`$id: ID!`)
+	checkErrorMessage(t, err, `Syntax Error GraphQL (1:1) Expected (, found $`)
+}
+
+func TestParseWithStats(t *testing.T) {
+	query := `
+# comments are not tokens
+query Q($id: ID) {
+  user(id: $id) {
+    name
+    friends { name }
+  }
+}
+fragment F on User { id }
+`
+	doc, stats, err := ParseWithStats(ParseParams{Source: query})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(doc.Definitions) != 2 {
+		t.Fatalf("expected 2 definitions, got: %v", doc.Definitions)
+	}
+	expected := Stats{Tokens: 31, MaxDepth: 3, Fields: 5, Definitions: 2}
+	if stats != expected {
+		t.Fatalf("expected stats %+v, got: %+v", expected, stats)
+	}
+
+	_, stats, err = ParseWithStats(ParseParams{Source: `{ field(`})
+	checkErrorMessage(t, err, `Syntax Error GraphQL (1:9) Expected Name, found EOF`)
+	if (stats != Stats{}) {
+		t.Fatalf("expected no stats on error, got: %+v", stats)
+	}
+}
+
+func TestRejectsNestingBeyondMaxDepth(t *testing.T) {
+	opts := ParseOptions{MaxDepth: 3}
+	if _, err := Parse(ParseParams{Source: `{ a { b { c } } }`, Options: opts}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tests := []errorMessageTest{
+		{
+			`{ a { b { c { d } } } }`,
+			`Syntax Error GraphQL (1:13) Nesting exceeds the maximum depth of 3`,
+			false,
+		},
+		{
+			`query Q($x: [[[[Int]]]]) { a }`,
+			`Syntax Error GraphQL (1:16) Nesting exceeds the maximum depth of 3`,
+			false,
+		},
+		{
+			`{ a(x: [{y: [[1]]}]) }`,
+			`Syntax Error GraphQL (1:13) Nesting exceeds the maximum depth of 3`,
+			false,
+		},
+	}
+	for _, test := range tests {
+		_, err := Parse(ParseParams{Source: test.source, Options: opts})
+		checkErrorMessage(t, err, test.expectedMessage)
+	}
+
+	// the depth unwinds when recovering, so later siblings are not rejected
+	_, err := Parse(ParseParams{
+		Source:  `{ a { b { c } } d { e } }`,
+		Options: ParseOptions{MaxDepth: 2, MaxErrors: 10},
+	})
+	if _, ok := err.(*gqlerrors.Error); !ok {
+		t.Fatalf("expected a single error, got: %v", err)
+	}
+	checkErrorMessage(t, err, `Syntax Error GraphQL (1:9) Nesting exceeds the maximum depth of 2`)
+}
+
+func TestCollectsSyntaxErrorsUpToMaxErrors(t *testing.T) {
+	source := `{
+  a(x: )
+  b
+}
+query Q { c { d: : e } f(y: 1) }`
+	_, err := Parse(ParseParams{Source: source, Options: ParseOptions{MaxErrors: 10}})
+	errs, ok := err.(Errors)
+	if !ok {
+		t.Fatalf("expected Errors, got: %v", err)
+	}
+	expected := []string{
+		`Syntax Error GraphQL (2:8) Unexpected )`,
+		`Syntax Error GraphQL (5:18) Expected Name, found :`,
+	}
+	if len(errs) != len(expected) {
+		t.Fatalf("expected %d errors, got: %v", len(expected), errs)
+	}
+	for i, message := range expected {
+		checkErrorMessage(t, errs[i], message)
+	}
+	if !strings.Contains(err.Error(), "(2:8)") || !strings.Contains(err.Error(), "(5:18)") {
+		t.Fatalf("expected all positions in the error message, got: %v", err)
+	}
+
+	_, err = Parse(ParseParams{Source: source, Options: ParseOptions{MaxErrors: 1}})
+	checkErrorMessage(t, err, `Syntax Error GraphQL (2:8) Unexpected )`)
+	if _, ok := err.(Errors); ok {
+		t.Fatalf("expected a single error without recovery, got: %v", err)
+	}
+
+	source = `{ a(x: ) b(y: ) c(z: ) }`
+	_, err = Parse(ParseParams{Source: source, Options: ParseOptions{MaxErrors: 2}})
+	if errs, ok := err.(Errors); !ok || len(errs) != 2 {
+		t.Fatalf("expected at most 2 errors, got: %v", err)
+	}
+
+	// errors outside selection sets and argument lists end the parse
+	source = `{ a(x: ) } query Q( { b }`
+	_, err = Parse(ParseParams{Source: source, Options: ParseOptions{MaxErrors: 10}})
+	if errs, ok := err.(Errors); !ok || len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got: %v", err)
+	}
+	checkErrorMessage(t, err.(Errors)[1], `Syntax Error GraphQL (1:21) Expected $, found {`)
+
+	if _, err := Parse(ParseParams{Source: `{ a(x: 1) }`, Options: ParseOptions{MaxErrors: 10}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// a single error is returned as is
+	_, err = Parse(ParseParams{Source: `{ a(x: ) b }`, Options: ParseOptions{MaxErrors: 10}})
+	if _, ok := err.(*gqlerrors.Error); !ok {
+		t.Fatalf("expected a single *gqlerrors.Error, got: %T %v", err, err)
+	}
+	checkErrorMessage(t, err, `Syntax Error GraphQL (1:8) Unexpected )`)
+
+	// brackets opened before the error are closed before resynchronizing
+	for _, source := range []string{
+		`{ a(x: [1, ) ] b }`,
+		`{ a(x: [1, ) ]) b }`,
+		`{ a(x: {y: [1, }]}) b }`,
+	} {
+		_, err = Parse(ParseParams{Source: source, Options: ParseOptions{MaxErrors: 10}})
+		if _, ok := err.(*gqlerrors.Error); !ok {
+			t.Fatalf("expected a single error for %v, got: %v", source, err)
+		}
+	}
+	_, err = Parse(ParseParams{Source: `{ a(x: [1, ) ]) b(y: ) }`, Options: ParseOptions{MaxErrors: 10}})
+	if errs, ok := err.(Errors); !ok || len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got: %v", err)
+	}
+	checkErrorMessage(t, err.(Errors)[0], `Syntax Error GraphQL (1:12) Unexpected )`)
+	checkErrorMessage(t, err.(Errors)[1], `Syntax Error GraphQL (1:22) Unexpected )`)
+}
+
+func TestAcceptsOptionToCanonicalizeValues(t *testing.T) {
+	tests := []struct {
+		literal   string
+		raw       string
+		canonical string
+	}{
+		{`1.10`, "1.10", "1.1"},
+		{`1e2`, "1e2", "100"},
+		{`-0.50E1`, "-0.50E1", "-5"},
+		{`1.5e-7`, "1.5e-7", "1.5e-7"},
+		{`6.02210e+023`, "6.02210e+023", "6.0221e23"},
+		{`1E-100`, "1E-100", "1e-100"},
+		{`-0`, "-0", "0"},
+		{`12345678901234567890123`, "12345678901234567890123", "12345678901234567890123"},
+	}
+	for _, test := range tests {
+		body := `{ f(a: ` + test.literal + `) }`
+		for _, canonicalize := range []bool{false, true} {
+			doc, err := Parse(ParseParams{
+				Source:  body,
+				Options: ParseOptions{CanonicalizeValues: canonicalize},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			field := doc.Definitions[0].(*ast.OperationDefinition).SelectionSet.Selections[0].(*ast.Field)
+			expected := test.raw
+			if canonicalize {
+				expected = test.canonical
+			}
+			if value := field.Arguments[0].Value.GetValue(); value != expected {
+				t.Fatalf("expected %v for %v (canonicalize: %v), got: %v", expected, test.literal, canonicalize, value)
+			}
+		}
+	}
+}
+
 func TestParseProvidesUsefulErrors(t *testing.T) {
 	opts := ParseOptions{
 		NoSource: true,
@@ -124,6 +504,16 @@ fragment MissingOn Type
 			`Syntax Error GraphQL (1:1) Unexpected ...`,
 			false,
 		},
+		{
+			"{\r\n  field(: 1) }",
+			`Syntax Error GraphQL (2:9) Expected Name, found :`,
+			false,
+		},
+		{
+			"{ field\n",
+			`Syntax Error GraphQL (2:1) Expected Name, found EOF`,
+			false,
+		},
 	}
 	for _, test := range testErrorMessagesTable {
 		if test.skipped != false {
@@ -159,12 +549,247 @@ func TestParsesVariableInlineValues(t *testing.T) {
 func TestParsesConstantDefaultValues(t *testing.T) {
 	test := errorMessageTest{
 		`query Foo($x: Complex = { a: { b: [ $var ] } }) { field }`,
-		`Syntax Error GraphQL (1:37) Unexpected $`,
+		`Syntax Error GraphQL (1:37) Unexpected $, variables are not allowed in constant positions`,
+		false,
+	}
+	testErrorMessage(t, test)
+}
+
+func TestParsesVariablesInArguments(t *testing.T) {
+	value := firstArgumentValue(t, `query Q($id: ID) { user(id: $id) }`)
+	expected := ast.NewVariable(&ast.Variable{
+		Name: ast.NewName(&ast.Name{
+			Value: "id",
+			Loc:   testLoc(29, 31),
+		}),
+		Loc: testLoc(28, 31),
+	})
+	if !reflect.DeepEqual(value, expected) {
+		t.Fatalf("unexpected value, expected: %v, got: %v", expected, value)
+	}
+}
+
+func TestDoesNotAllowVariablesInDefaultValues(t *testing.T) {
+	test := errorMessageTest{
+		`query Foo($x: Int = $y) { field }`,
+		`Syntax Error GraphQL (1:21) Unexpected $, variables are not allowed in constant positions`,
 		false,
 	}
 	testErrorMessage(t, test)
 }
 
+func TestParsesTypeReferences(t *testing.T) {
+	tests := []struct {
+		source string
+		kinds  []string
+	}{
+		{`Int`, []string{kinds.Named}},
+		{`Int!`, []string{kinds.NonNull, kinds.Named}},
+		{`[Int]`, []string{kinds.List, kinds.Named}},
+		{`[Int!]!`, []string{kinds.NonNull, kinds.List, kinds.NonNull, kinds.Named}},
+		{`[[String]]`, []string{kinds.List, kinds.List, kinds.Named}},
+		{`[[String!]]!`, []string{kinds.NonNull, kinds.List, kinds.List, kinds.NonNull, kinds.Named}},
+	}
+	for _, test := range tests {
+		doc := parse(t, `query Q($x: `+test.source+`) { field }`)
+		ttype := doc.Definitions[0].(*ast.OperationDefinition).VariableDefinitions[0].Type
+		if str := ast.TypeString(ttype); str != test.source {
+			t.Fatalf("expected type %v, got: %v", test.source, str)
+		}
+		for i, kind := range test.kinds {
+			if ttype == nil || ttype.GetKind() != kind {
+				t.Fatalf("expected %v at depth %d of %v, got: %v", kind, i, test.source, ttype)
+			}
+			switch wrapper := ttype.(type) {
+			case *ast.List:
+				ttype = wrapper.Type
+			case *ast.NonNull:
+				ttype = wrapper.Type
+			default:
+				ttype = nil
+			}
+		}
+	}
+}
+
+func TestDoesNotAcceptMalformedListTypes(t *testing.T) {
+	tests := []errorMessageTest{
+		{`query Q($x: [Int) { field }`, `Syntax Error GraphQL (1:17) Expected ], found )`, false},
+		{`query Q($x: ]) { field }`, `Syntax Error GraphQL (1:13) Unexpected ]`, false},
+		{`query Q($x: {Int]) { field }`, `Syntax Error GraphQL (1:13) Unexpected {`, false},
+	}
+	for _, test := range tests {
+		testErrorMessage(t, test)
+	}
+}
+
+func TestParsesVariableDefinitions(t *testing.T) {
+	tests := []struct {
+		source       string
+		ttype        string
+		defaultKind  string
+		defaultValue interface{}
+	}{
+		{`$x: Int`, `Int`, "", nil},
+		{`$x: Int = 3`, `Int`, kinds.IntValue, "3"},
+		{`$x: [String!]! = []`, `[String!]!`, kinds.ListValue, nil},
+	}
+	for _, test := range tests {
+		doc := parse(t, `query Q(`+test.source+`) { field }`)
+		defs := doc.Definitions[0].(*ast.OperationDefinition).VariableDefinitions
+		if len(defs) != 1 {
+			t.Fatalf("expected one variable definition for %q, got: %v", test.source, defs)
+		}
+		def := defs[0]
+		if def.Variable.Name.Value != "x" {
+			t.Fatalf("unexpected variable for %q: %v", test.source, def.Variable.Name.Value)
+		}
+		if ttype := ast.TypeString(def.Type); ttype != test.ttype {
+			t.Fatalf("expected type %v for %q, got: %v", test.ttype, test.source, ttype)
+		}
+		if test.defaultKind == "" {
+			if def.DefaultValue != nil {
+				t.Fatalf("expected no default value for %q, got: %v", test.source, def.DefaultValue)
+			}
+			continue
+		}
+		if def.DefaultValue == nil || def.DefaultValue.GetKind() != test.defaultKind {
+			t.Fatalf("expected a %v default for %q, got: %v", test.defaultKind, test.source, def.DefaultValue)
+		}
+		if test.defaultValue != nil && def.DefaultValue.GetValue() != test.defaultValue {
+			t.Fatalf("expected default %v for %q, got: %v", test.defaultValue, test.source, def.DefaultValue.GetValue())
+		}
+		if !reflect.DeepEqual(def.Loc, testLoc(8, 8+len(test.source))) {
+			t.Fatalf("unexpected location for %q: %v", test.source, def.Loc)
+		}
+	}
+}
+
+func TestParsesFieldArgumentsBeforeSelectionSet(t *testing.T) {
+	doc := parse(t, `{ u: user(id: 1) @include(if: true) { name } avatar(size: 64) }`)
+	selections := doc.Definitions[0].(*ast.OperationDefinition).SelectionSet.Selections
+	if len(selections) != 2 {
+		t.Fatalf("expected two fields, got: %v", selections)
+	}
+	user := selections[0].(*ast.Field)
+	if user.Alias.Value != "u" || user.Name.Value != "user" {
+		t.Fatalf("unexpected alias and name: %v, %v", user.Alias, user.Name)
+	}
+	if len(user.Arguments) != 1 || user.Arguments[0].Name.Value != "id" {
+		t.Fatalf("unexpected arguments: %v", user.Arguments)
+	}
+	if len(user.Directives) != 1 || user.Directives[0].Name.Value != "include" {
+		t.Fatalf("unexpected directives: %v", user.Directives)
+	}
+	if user.SelectionSet == nil || len(user.SelectionSet.Selections) != 1 {
+		t.Fatalf("expected a nested selection, got: %v", user.SelectionSet)
+	}
+
+	avatar := selections[1].(*ast.Field)
+	if len(avatar.Arguments) != 1 || avatar.Arguments[0].Value.GetValue() != "64" {
+		t.Fatalf("unexpected arguments: %v", avatar.Arguments)
+	}
+	if avatar.SelectionSet != nil {
+		t.Fatalf("expected a leaf field, got: %v", avatar.SelectionSet)
+	}
+	if !reflect.DeepEqual(avatar.Loc, testLoc(45, 61)) {
+		t.Fatalf("unexpected leaf field location: %v", avatar.Loc)
+	}
+}
+
+func TestParsesDirectiveArguments(t *testing.T) {
+	doc := parse(t, `{ a @include(if: true) b @deprecated c @cached(ttl: 60, scope: PRIVATE) }`)
+	selections := doc.Definitions[0].(*ast.OperationDefinition).SelectionSet.Selections
+	tests := []struct {
+		name      string
+		arguments []string
+	}{
+		{"include", []string{"if"}},
+		{"deprecated", []string{}},
+		{"cached", []string{"ttl", "scope"}},
+	}
+	for i, test := range tests {
+		directives := selections[i].(*ast.Field).Directives
+		if len(directives) != 1 || directives[0].Name.Value != test.name {
+			t.Fatalf("expected directive @%v, got: %v", test.name, directives)
+		}
+		names := []string{}
+		for _, arg := range directives[0].Arguments {
+			names = append(names, arg.Name.Value)
+		}
+		if !reflect.DeepEqual(names, test.arguments) {
+			t.Fatalf("expected arguments %v on @%v, got: %v", test.arguments, test.name, names)
+		}
+	}
+}
+
+func TestDoesNotAcceptDirectiveWithColonValue(t *testing.T) {
+	test := errorMessageTest{
+		`{ a @include: true }`,
+		`Syntax Error GraphQL (1:13) Expected Name, found :`,
+		false,
+	}
+	testErrorMessage(t, test)
+}
+
+func TestParsesMultipleVariableDefinitions(t *testing.T) {
+	doc := parse(t, `query Q($a: Int, $b: String!) { field }`)
+	defs := doc.Definitions[0].(*ast.OperationDefinition).VariableDefinitions
+	if len(defs) != 2 {
+		t.Fatalf("expected two variable definitions, got: %v", defs)
+	}
+	for i, expected := range []string{"a", "b"} {
+		if name := defs[i].Variable.Name.Value; name != expected {
+			t.Fatalf("expected variable %v at index %d, got: %v", expected, i, name)
+		}
+	}
+}
+
+func TestParsesArgumentsInOrder(t *testing.T) {
+	doc := parse(t, `{ f(a: 1, b: 2) }`)
+	field := doc.Definitions[0].(*ast.OperationDefinition).SelectionSet.Selections[0].(*ast.Field)
+	expected := []*ast.Argument{
+		ast.NewArgument(&ast.Argument{
+			Name:  ast.NewName(&ast.Name{Value: "a", Loc: testLoc(4, 5)}),
+			Value: ast.NewIntValue(&ast.IntValue{Value: "1", Loc: testLoc(7, 8)}),
+			Loc:   testLoc(4, 8),
+		}),
+		ast.NewArgument(&ast.Argument{
+			Name:  ast.NewName(&ast.Name{Value: "b", Loc: testLoc(10, 11)}),
+			Value: ast.NewIntValue(&ast.IntValue{Value: "2", Loc: testLoc(13, 14)}),
+			Loc:   testLoc(10, 14),
+		}),
+	}
+	if !reflect.DeepEqual(field.Arguments, expected) {
+		t.Fatalf("unexpected arguments, expected: %v, got: %v", expected, field.Arguments)
+	}
+}
+
+func TestReportsLexerErrorsAfterSkippedTokens(t *testing.T) {
+	tests := []errorMessageTest{
+		{`type A implements & ~B { a: Int }`, `Syntax Error GraphQL (1:21) Unexpected character "~".`, false},
+		{`type A implements B & ~C { a: Int }`, `Syntax Error GraphQL (1:23) Unexpected character "~".`, false},
+		{`query Q($a: Int = ~1) { a }`, `Syntax Error GraphQL (1:19) Unexpected character "~".`, false},
+		{`{ a: ~b }`, `Syntax Error GraphQL (1:6) Unexpected character "~".`, false},
+	}
+	for _, test := range tests {
+		testErrorMessage(t, test)
+	}
+}
+
+func TestReportsUnexpectedTokenAtItsOwnPosition(t *testing.T) {
+	tests := []errorMessageTest{
+		// the operation type has already been consumed when it is rejected
+		{`schema { foo: Query }`, `Syntax Error GraphQL (1:10) Unexpected Name "foo"`, false},
+		// the keyword is found by looking past the description
+		{`"description" foo Bar`, `Syntax Error GraphQL (1:15) Unexpected Name "foo"`, false},
+		{`fragment on on User { a }`, `Syntax Error GraphQL (1:10) Unexpected Name "on"`, false},
+	}
+	for _, test := range tests {
+		testErrorMessage(t, test)
+	}
+}
+
 func TestDoesNotAcceptFragmentsNameOn(t *testing.T) {
 	test := errorMessageTest{
 		`fragment on on on { on }`,
@@ -183,15 +808,344 @@ func TestDoesNotAcceptFragmentsSpreadOfOn(t *testing.T) {
 	testErrorMessage(t, test)
 }
 
-func TestDoesNotAllowNullAsValue(t *testing.T) {
+func TestParsesNullAsValue(t *testing.T) {
+	value := firstArgumentValue(t, `{ fieldWithNullableStringInput(input: null) }`)
+	expected := ast.NewNullValue(&ast.NullValue{
+		Loc: testLoc(38, 42),
+	})
+	if !reflect.DeepEqual(value, expected) {
+		t.Fatalf("unexpected value, expected: %v, got: %v", expected, value)
+	}
+	if value.GetValue() != nil {
+		t.Fatalf("expected null value to be nil, got: %v", value.GetValue())
+	}
+}
+
+func TestParsesNullPrefixedNameAsEnumValue(t *testing.T) {
+	value := firstArgumentValue(t, `{ field(arg: nullish) }`)
+	expected := ast.NewEnumValue(&ast.EnumValue{
+		Value: "nullish",
+		Loc:   testLoc(13, 20),
+	})
+	if !reflect.DeepEqual(value, expected) {
+		t.Fatalf("unexpected value, expected: %v, got: %v", expected, value)
+	}
+}
+
+func TestNameLocationsCoverTheirIdentifiers(t *testing.T) {
+	body := `{ hello, world: greeting(to: you) }`
+	astDoc, err := Parse(ParseParams{Source: body, Options: ParseOptions{NoSource: true}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	selections := astDoc.Definitions[0].(*ast.OperationDefinition).SelectionSet.Selections
+	hello := selections[0].(*ast.Field)
+	greeting := selections[1].(*ast.Field)
+	names := []*ast.Name{hello.Name, greeting.Alias, greeting.Name, greeting.Arguments[0].Name}
+	expected := []string{"hello", "world", "greeting", "to"}
+	for i, name := range names {
+		if text := body[name.Loc.Start:name.Loc.End]; text != expected[i] || name.Value != expected[i] {
+			t.Fatalf("expected name %q to span its identifier, got %q at %v", expected[i], text, name.Loc)
+		}
+	}
+	if !reflect.DeepEqual(hello.Name.Loc, testLoc(2, 7)) {
+		t.Fatalf("unexpected name location: %v", hello.Name.Loc)
+	}
+}
+
+func TestDoesNotAllowEmptyArguments(t *testing.T) {
+	test := errorMessageTest{
+		`{ field() }`,
+		`Syntax Error GraphQL (1:9) Expected at least one argument, found ')'`,
+		false,
+	}
+	testErrorMessage(t, test)
+}
+
+func TestDoesNotAllowEmptyVariableDefinitions(t *testing.T) {
+	test := errorMessageTest{
+		`query Foo() { field }`,
+		`Syntax Error GraphQL (1:11) Expected variable definition, found ')'`,
+		false,
+	}
+	testErrorMessage(t, test)
+
+	op := parse(t, `query Foo { field }`).Definitions[0].(*ast.OperationDefinition)
+	if len(op.VariableDefinitions) != 0 {
+		t.Fatalf("expected no variable definitions, got: %v", op.VariableDefinitions)
+	}
+	if _, err := ParseVariableDefinitions(`()`); err == nil {
+		t.Fatalf("expected empty variable definitions to fail")
+	}
+}
+
+// firstArgumentValue returns the value of the first argument of the first
+// field in the given query.
+func firstArgumentValue(t *testing.T, query string) ast.Value {
+	doc := parse(t, query)
+	op := doc.Definitions[0].(*ast.OperationDefinition)
+	field := op.SelectionSet.Selections[0].(*ast.Field)
+	if len(field.Arguments) == 0 {
+		t.Fatalf("expected at least one argument in %q", query)
+	}
+	return field.Arguments[0].Value
+}
+
+func TestParsesIntValues(t *testing.T) {
+	tests := []struct {
+		query string
+		value string
+		loc   *ast.Location
+	}{
+		{`{ f(x: 0) }`, "0", testLoc(7, 8)},
+		{`{ f(x: -42) }`, "-42", testLoc(7, 10)},
+		{`{ f(x: 9007199254740993) }`, "9007199254740993", testLoc(7, 23)},
+	}
+	for _, test := range tests {
+		expected := ast.NewIntValue(&ast.IntValue{
+			Value: test.value,
+			Loc:   test.loc,
+		})
+		value := firstArgumentValue(t, test.query)
+		if !reflect.DeepEqual(value, expected) {
+			t.Fatalf("unexpected value for %q, expected: %v, got: %v", test.query, expected, value)
+		}
+	}
+}
+
+func TestParsesFloatValues(t *testing.T) {
+	tests := []struct {
+		query string
+		value string
+		loc   *ast.Location
+	}{
+		{`{ f(x: 1.5) }`, "1.5", testLoc(7, 10)},
+		{`{ f(x: 1e50) }`, "1e50", testLoc(7, 11)},
+		{`{ f(x: 6.0221e23) }`, "6.0221e23", testLoc(7, 16)},
+	}
+	for _, test := range tests {
+		expected := ast.NewFloatValue(&ast.FloatValue{
+			Value: test.value,
+			Loc:   test.loc,
+		})
+		value := firstArgumentValue(t, test.query)
+		if value.GetKind() != kinds.FloatValue {
+			t.Fatalf("unexpected kind for %q, expected: %v, got: %v", test.query, kinds.FloatValue, value.GetKind())
+		}
+		if !reflect.DeepEqual(value, expected) {
+			t.Fatalf("unexpected value for %q, expected: %v, got: %v", test.query, expected, value)
+		}
+	}
+}
+
+func TestParsesStringValues(t *testing.T) {
+	tests := []struct {
+		query string
+		value string
+		loc   *ast.Location
+	}{
+		{`{ f(x: "") }`, "", testLoc(7, 9)},
+		{`{ f(x: "hi") }`, "hi", testLoc(7, 11)},
+		{`{ f(x: "with some spaces") }`, "with some spaces", testLoc(7, 25)},
+	}
+	for _, test := range tests {
+		expected := ast.NewStringValue(&ast.StringValue{
+			Value: test.value,
+			Loc:   test.loc,
+		})
+		value := firstArgumentValue(t, test.query)
+		if !reflect.DeepEqual(value, expected) {
+			t.Fatalf("unexpected value for %q, expected: %v, got: %v", test.query, expected, value)
+		}
+	}
+
+	doc := parse(t, `query Q($msg: String = "hello there") { greeting(msg: $msg) }`)
+	op := doc.Definitions[0].(*ast.OperationDefinition)
+	expected := ast.NewStringValue(&ast.StringValue{
+		Value: "hello there",
+		Loc:   testLoc(23, 36),
+	})
+	if defaultValue := op.VariableDefinitions[0].DefaultValue; !reflect.DeepEqual(defaultValue, expected) {
+		t.Fatalf("unexpected default value, expected: %v, got: %v", expected, defaultValue)
+	}
+}
+
+func TestParsesBooleanValues(t *testing.T) {
+	for _, test := range []struct {
+		query string
+		value bool
+	}{
+		{`{ node(active: true) }`, true},
+		{`{ node(active: false) }`, false},
+	} {
+		value, ok := firstArgumentValue(t, test.query).(*ast.BooleanValue)
+		if !ok || value.Value != test.value {
+			t.Fatalf("expected boolean %v for %q, got: %v", test.value, test.query, value)
+		}
+	}
+
+	list, ok := firstArgumentValue(t, `{ node(flags: [true, false]) }`).(*ast.ListValue)
+	if !ok || len(list.Values) != 2 {
+		t.Fatalf("expected a two item list, got: %v", list)
+	}
+	for i, expected := range []bool{true, false} {
+		value, ok := list.Values[i].(*ast.BooleanValue)
+		if !ok || value.Value != expected {
+			t.Fatalf("expected boolean %v at index %d, got: %v", expected, i, list.Values[i])
+		}
+	}
+}
+
+func TestParsesEnumValues(t *testing.T) {
+	value := firstArgumentValue(t, `{ move(direction: NORTH) }`)
+	expected := ast.NewEnumValue(&ast.EnumValue{
+		Value: "NORTH",
+		Loc:   testLoc(18, 23),
+	})
+	if !reflect.DeepEqual(value, expected) {
+		t.Fatalf("unexpected value, expected: %v, got: %v", expected, value)
+	}
+
+	list, ok := firstArgumentValue(t, `{ move(path: [NORTH, EAST]) }`).(*ast.ListValue)
+	if !ok || len(list.Values) != 2 {
+		t.Fatalf("expected a two item list, got: %v", list)
+	}
+	for i, expected := range []string{"NORTH", "EAST"} {
+		value, ok := list.Values[i].(*ast.EnumValue)
+		if !ok || value.Value != expected {
+			t.Fatalf("expected enum %v at index %d, got: %v", expected, i, list.Values[i])
+		}
+	}
+}
+
+func TestParsesObjectValues(t *testing.T) {
+	value := firstArgumentValue(t, `{ users(filter: { name: "x", age: 5, tags: ["a"], friend: { name: $friend } }) }`)
+	object, ok := value.(*ast.ObjectValue)
+	if !ok || len(object.Fields) != 4 {
+		t.Fatalf("expected an object with four fields, got: %v", value)
+	}
+	for i, expected := range []string{kinds.StringValue, kinds.IntValue, kinds.ListValue, kinds.ObjectValue} {
+		if kind := object.Fields[i].Value.GetKind(); kind != expected {
+			t.Fatalf("expected field %d to be %v, got: %v", i, expected, kind)
+		}
+	}
+	tags := object.Fields[2].Value.(*ast.ListValue)
+	if len(tags.Values) != 1 || tags.Values[0].GetValue() != "a" {
+		t.Fatalf("unexpected list field value: %v", tags)
+	}
+	friend := object.Fields[3].Value.(*ast.ObjectValue)
+	if len(friend.Fields) != 1 || friend.Fields[0].Name.Value != "name" {
+		t.Fatalf("unexpected nested object: %v", friend)
+	}
+	if _, ok := friend.Fields[0].Value.(*ast.Variable); !ok {
+		t.Fatalf("expected a nested variable, got: %v", friend.Fields[0].Value)
+	}
+
+	empty, ok := firstArgumentValue(t, `{ users(filter: {}) }`).(*ast.ObjectValue)
+	if !ok || len(empty.Fields) != 0 {
+		t.Fatalf("expected an empty object, got: %v", empty)
+	}
+	if !reflect.DeepEqual(empty.Loc, testLoc(16, 18)) {
+		t.Fatalf("unexpected empty object location: %v", empty.Loc)
+	}
+}
+
+func TestDoesNotAllowVariablesInsideConstantObjects(t *testing.T) {
+	_, err := Parse(ParseParams{
+		Source: `query Foo($f: Filter = { friend: { name: $name } }) { users }`,
+	})
+	if err == nil {
+		t.Fatalf("expected a variable inside a constant object to be rejected")
+	}
+}
+
+func TestParsesQuotedEnumLikeValueAsString(t *testing.T) {
+	quoted := firstArgumentValue(t, `{ users(status: "ACTIVE") }`)
+	if value, ok := quoted.(*ast.StringValue); !ok || value.Value != "ACTIVE" {
+		t.Fatalf("expected a StringValue, got: %v", quoted)
+	}
+	bare := firstArgumentValue(t, `{ users(status: ACTIVE) }`)
+	if value, ok := bare.(*ast.EnumValue); !ok || value.Value != "ACTIVE" {
+		t.Fatalf("expected an EnumValue, got: %v", bare)
+	}
+}
+
+func TestDoesNotAllowEscapeSequencesInEnumValues(t *testing.T) {
 	test := errorMessageTest{
-		`{ fieldWithNullableStringInput(input: null) }'`,
-		`Syntax Error GraphQL (1:39) Unexpected Name "null"`,
+		`{ users(status: ACT\u0049VE) }`,
+		`Syntax Error GraphQL (1:20) Unexpected character "\".`,
 		false,
 	}
 	testErrorMessage(t, test)
 }
 
+func TestParsesListValues(t *testing.T) {
+	tests := []struct {
+		query string
+		kinds []string
+		loc   *ast.Location
+	}{
+		{`{ f(a: [1, 2, 3]) }`, []string{kinds.IntValue, kinds.IntValue, kinds.IntValue}, testLoc(7, 16)},
+		{`{ f(a: []) }`, []string{}, testLoc(7, 9)},
+		{`query Q($a: Int, $b: Int) { f(a: [$a, $b]) }`, []string{kinds.Variable, kinds.Variable}, testLoc(33, 41)},
+	}
+	for _, test := range tests {
+		list, ok := firstArgumentValue(t, test.query).(*ast.ListValue)
+		if !ok {
+			t.Fatalf("expected a list value for %q", test.query)
+		}
+		valueKinds := []string{}
+		for _, value := range list.Values {
+			valueKinds = append(valueKinds, value.GetKind())
+		}
+		if !reflect.DeepEqual(valueKinds, test.kinds) {
+			t.Fatalf("expected items %v for %q, got: %v", test.kinds, test.query, valueKinds)
+		}
+		if !reflect.DeepEqual(list.Loc, test.loc) {
+			t.Fatalf("unexpected location for %q: %v", test.query, list.Loc)
+		}
+	}
+}
+
+func TestParsesDeeplyNestedListsOfObjects(t *testing.T) {
+	value := firstArgumentValue(t, `{ filter(where: [{and: [{eq: {field: "x", value: 1}}]}]) }`)
+	where, ok := value.(*ast.ListValue)
+	if !ok || len(where.Values) != 1 {
+		t.Fatalf("expected a one item list, got: %v", value)
+	}
+	outer, ok := where.Values[0].(*ast.ObjectValue)
+	if !ok || len(outer.Fields) != 1 || outer.Fields[0].Name.Value != "and" {
+		t.Fatalf("expected an object with an and field, got: %v", where.Values[0])
+	}
+	and, ok := outer.Fields[0].Value.(*ast.ListValue)
+	if !ok || len(and.Values) != 1 {
+		t.Fatalf("expected and to be a one item list, got: %v", outer.Fields[0].Value)
+	}
+	condition, ok := and.Values[0].(*ast.ObjectValue)
+	if !ok || len(condition.Fields) != 1 || condition.Fields[0].Name.Value != "eq" {
+		t.Fatalf("expected an object with an eq field, got: %v", and.Values[0])
+	}
+	eq, ok := condition.Fields[0].Value.(*ast.ObjectValue)
+	if !ok || len(eq.Fields) != 2 {
+		t.Fatalf("expected eq to be an object with two fields, got: %v", condition.Fields[0].Value)
+	}
+	expected := []*ast.ObjectField{
+		ast.NewObjectField(&ast.ObjectField{
+			Name:  ast.NewName(&ast.Name{Value: "field", Loc: testLoc(30, 35)}),
+			Value: ast.NewStringValue(&ast.StringValue{Value: "x", Loc: testLoc(37, 40)}),
+			Loc:   testLoc(30, 40),
+		}),
+		ast.NewObjectField(&ast.ObjectField{
+			Name:  ast.NewName(&ast.Name{Value: "value", Loc: testLoc(42, 47)}),
+			Value: ast.NewIntValue(&ast.IntValue{Value: "1", Loc: testLoc(49, 50)}),
+			Loc:   testLoc(42, 50),
+		}),
+	}
+	if !reflect.DeepEqual(eq.Fields, expected) {
+		t.Fatalf("unexpected innermost fields, expected: %v, got: %v", expected, eq.Fields)
+	}
+}
+
 func TestParsesMultiByteCharacters_Unicode(t *testing.T) {
 
 	doc := `
@@ -346,6 +1300,18 @@ func TestParsesMultiByteCharacters_UnicodeText(t *testing.T) {
 	}
 }
 
+func TestParsesCommentOnlyDocumentAsEmpty(t *testing.T) {
+	for _, body := range []string{"", "  \n\t,", "# just a comment", "# one\n  # two\n"} {
+		doc, err := Parse(ParseParams{Source: body})
+		if err != nil {
+			t.Fatalf("unexpected error for %q: %v", body, err)
+		}
+		if len(doc.Definitions) != 0 {
+			t.Fatalf("expected no definitions for %q, got: %v", body, doc.Definitions)
+		}
+	}
+}
+
 func TestParsesKitchenSink(t *testing.T) {
 	b, err := ioutil.ReadFile("../../kitchen-sink.graphql")
 	if err != nil {
@@ -389,6 +1355,53 @@ func TestAllowsNonKeywordsAnywhereNameIsAllowed(t *testing.T) {
 	}
 }
 
+func TestParsesInlineFragmentWithDirectiveArguments(t *testing.T) {
+	doc := parse(t, `query Q($x: Boolean) { ... on User @include(if: $x) { name } }`)
+	op := doc.Definitions[0].(*ast.OperationDefinition)
+	fragment, ok := op.SelectionSet.Selections[0].(*ast.InlineFragment)
+	if !ok {
+		t.Fatalf("expected an inline fragment, got: %T", op.SelectionSet.Selections[0])
+	}
+	if fragment.TypeCondition == nil || fragment.TypeCondition.Name.Value != "User" {
+		t.Fatalf("unexpected type condition: %v", fragment.TypeCondition)
+	}
+	if len(fragment.Directives) != 1 {
+		t.Fatalf("expected one directive, got: %v", len(fragment.Directives))
+	}
+	directive := fragment.Directives[0]
+	if directive.Name.Value != "include" || len(directive.Arguments) != 1 {
+		t.Fatalf("unexpected directive: %v", directive)
+	}
+	arg := directive.Arguments[0]
+	variable, ok := arg.Value.(*ast.Variable)
+	if arg.Name.Value != "if" || !ok || variable.Name.Value != "x" {
+		t.Fatalf("unexpected directive argument: %v", arg)
+	}
+	if len(fragment.SelectionSet.Selections) != 1 {
+		t.Fatalf("expected one selection, got: %v", len(fragment.SelectionSet.Selections))
+	}
+}
+
+func TestParsesMixedSelectionKindsInOrder(t *testing.T) {
+	doc := parse(t, `{ a ...Frag ... on T { b } c }`)
+	selections := doc.Definitions[0].(*ast.OperationDefinition).SelectionSet.Selections
+	if len(selections) != 4 {
+		t.Fatalf("expected four selections, got: %v", len(selections))
+	}
+	if field, ok := selections[0].(*ast.Field); !ok || field.Name.Value != "a" {
+		t.Fatalf("expected field a first, got: %v", selections[0])
+	}
+	if spread, ok := selections[1].(*ast.FragmentSpread); !ok || spread.Name.Value != "Frag" {
+		t.Fatalf("expected spread of Frag second, got: %v", selections[1])
+	}
+	if fragment, ok := selections[2].(*ast.InlineFragment); !ok || fragment.TypeCondition.Name.Value != "T" {
+		t.Fatalf("expected inline fragment on T third, got: %v", selections[2])
+	}
+	if field, ok := selections[3].(*ast.Field); !ok || field.Name.Value != "c" {
+		t.Fatalf("expected field c last, got: %v", selections[3])
+	}
+}
+
 func TestParsesExperimentalSubscriptionFeature(t *testing.T) {
 	source := `
       subscription Foo {
@@ -449,6 +1462,34 @@ func TestParsesNamedSubscriptionOperations(t *testing.T) {
 	}
 }
 
+func TestParsesOperationTypes(t *testing.T) {
+	astDoc := parse(t, `
+subscription onMessage($room: ID!) { messageAdded(room: $room) { text } }
+{ shorthand }
+query { anonymous }
+mutation M { change }
+`)
+	expected := []string{
+		ast.OperationTypeSubscription,
+		ast.OperationTypeQuery,
+		ast.OperationTypeQuery,
+		ast.OperationTypeMutation,
+	}
+	for i, operation := range expected {
+		op := astDoc.Definitions[i].(*ast.OperationDefinition)
+		if op.Operation != operation {
+			t.Fatalf("expected operation %d to be a %v, got: %v", i, operation, op.Operation)
+		}
+	}
+	subscription := astDoc.Definitions[0].(*ast.OperationDefinition)
+	if subscription.Name.Value != "onMessage" || len(subscription.VariableDefinitions) != 1 {
+		t.Fatalf("unexpected subscription header: %v", subscription)
+	}
+	if field := subscription.SelectionSet.Selections[0].(*ast.Field); field.Name.Value != "messageAdded" || field.SelectionSet == nil {
+		t.Fatalf("unexpected subscription selection: %v", field)
+	}
+}
+
 func TestParsesFieldDefinitionWithDescription(t *testing.T) {
 	source := `
 		type Foo implements Bar {
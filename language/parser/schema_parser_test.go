@@ -6,6 +6,7 @@ import (
 
 	"github.com/graphql-go/graphql/gqlerrors"
 	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/kinds"
 	"github.com/graphql-go/graphql/language/location"
 	"github.com/graphql-go/graphql/language/source"
 )
@@ -74,6 +75,91 @@ type Hello {
 	}
 }
 
+func TestSchemaParser_TypeWithDirectivesAndMultipleFields(t *testing.T) {
+
+	body := `
+type User @entity(table: "users") {
+  id: ID!
+  name: String
+}`
+	astDoc := parse(t, body)
+	expected := ast.NewDocument(&ast.Document{
+		Loc: testLoc(1, 63),
+		Definitions: []ast.Node{
+			ast.NewObjectDefinition(&ast.ObjectDefinition{
+				Loc: testLoc(1, 63),
+				Name: ast.NewName(&ast.Name{
+					Value: "User",
+					Loc:   testLoc(6, 10),
+				}),
+				Directives: []*ast.Directive{
+					ast.NewDirective(&ast.Directive{
+						Loc: testLoc(11, 34),
+						Name: ast.NewName(&ast.Name{
+							Value: "entity",
+							Loc:   testLoc(12, 18),
+						}),
+						Arguments: []*ast.Argument{
+							ast.NewArgument(&ast.Argument{
+								Loc: testLoc(19, 33),
+								Name: ast.NewName(&ast.Name{
+									Value: "table",
+									Loc:   testLoc(19, 24),
+								}),
+								Value: ast.NewStringValue(&ast.StringValue{
+									Value: "users",
+									Loc:   testLoc(26, 33),
+								}),
+							}),
+						},
+					}),
+				},
+				Interfaces: []*ast.Named{},
+				Fields: []*ast.FieldDefinition{
+					ast.NewFieldDefinition(&ast.FieldDefinition{
+						Loc: testLoc(39, 46),
+						Name: ast.NewName(&ast.Name{
+							Value: "id",
+							Loc:   testLoc(39, 41),
+						}),
+						Arguments:  []*ast.InputValueDefinition{},
+						Directives: []*ast.Directive{},
+						Type: ast.NewNonNull(&ast.NonNull{
+							Loc: testLoc(43, 46),
+							Type: ast.NewNamed(&ast.Named{
+								Loc: testLoc(43, 45),
+								Name: ast.NewName(&ast.Name{
+									Value: "ID",
+									Loc:   testLoc(43, 45),
+								}),
+							}),
+						}),
+					}),
+					ast.NewFieldDefinition(&ast.FieldDefinition{
+						Loc: testLoc(49, 61),
+						Name: ast.NewName(&ast.Name{
+							Value: "name",
+							Loc:   testLoc(49, 53),
+						}),
+						Arguments:  []*ast.InputValueDefinition{},
+						Directives: []*ast.Directive{},
+						Type: ast.NewNamed(&ast.Named{
+							Loc: testLoc(55, 61),
+							Name: ast.NewName(&ast.Name{
+								Value: "String",
+								Loc:   testLoc(55, 61),
+							}),
+						}),
+					}),
+				},
+			}),
+		},
+	})
+	if !reflect.DeepEqual(astDoc, expected) {
+		t.Fatalf("unexpected document, expected: %v, got: %v", expected, astDoc)
+	}
+}
+
 func TestSchemaParser_SimpleExtension(t *testing.T) {
 
 	body := `
@@ -121,6 +207,87 @@ extend type Hello {
 	}
 }
 
+func TestSchemaParser_ExtensionWithDirective(t *testing.T) {
+	body := `extend type User @key(fields: "id") { email: String }`
+	astDoc := parse(t, body)
+	extension, ok := astDoc.Definitions[0].(*ast.TypeExtensionDefinition)
+	if !ok {
+		t.Fatalf("expected a type extension, got: %v", astDoc.Definitions[0])
+	}
+	definition := extension.Definition
+	if definition.Name.Value != "User" || len(definition.Fields) != 1 || definition.Fields[0].Name.Value != "email" {
+		t.Fatalf("unexpected extended type: %v", definition)
+	}
+	if len(definition.Directives) != 1 || definition.Directives[0].Name.Value != "key" {
+		t.Fatalf("expected directive @key, got: %v", definition.Directives)
+	}
+	if !reflect.DeepEqual(extension.Loc, testLoc(0, len(body))) {
+		t.Fatalf("unexpected extension location: %v", extension.Loc)
+	}
+}
+
+func TestSchemaParser_InterfaceExtension(t *testing.T) {
+	body := `
+extend interface Node @shared {
+  createdAt: String
+}`
+	astDoc := parse(t, body)
+	expected := ast.NewDocument(&ast.Document{
+		Loc: testLoc(1, 54),
+		Definitions: []ast.Node{
+			ast.NewInterfaceExtensionDefinition(&ast.InterfaceExtensionDefinition{
+				Loc: testLoc(1, 54),
+				Definition: ast.NewInterfaceDefinition(&ast.InterfaceDefinition{
+					Loc: testLoc(8, 54),
+					Name: ast.NewName(&ast.Name{
+						Value: "Node",
+						Loc:   testLoc(18, 22),
+					}),
+					Directives: []*ast.Directive{
+						ast.NewDirective(&ast.Directive{
+							Loc: testLoc(23, 30),
+							Name: ast.NewName(&ast.Name{
+								Value: "shared",
+								Loc:   testLoc(24, 30),
+							}),
+							Arguments: []*ast.Argument{},
+						}),
+					},
+					Fields: []*ast.FieldDefinition{
+						ast.NewFieldDefinition(&ast.FieldDefinition{
+							Loc: testLoc(35, 52),
+							Name: ast.NewName(&ast.Name{
+								Value: "createdAt",
+								Loc:   testLoc(35, 44),
+							}),
+							Directives: []*ast.Directive{},
+							Arguments:  []*ast.InputValueDefinition{},
+							Type: ast.NewNamed(&ast.Named{
+								Loc: testLoc(46, 52),
+								Name: ast.NewName(&ast.Name{
+									Value: "String",
+									Loc:   testLoc(46, 52),
+								}),
+							}),
+						}),
+					},
+				}),
+			}),
+		},
+	})
+	if !reflect.DeepEqual(astDoc, expected) {
+		t.Fatalf("unexpected document, expected: %v, got: %v", expected, astDoc)
+	}
+}
+
+func TestSchemaParser_ExtensionOfUnsupportedKind(t *testing.T) {
+	testErrorMessage(t, errorMessageTest{
+		`extend union Pet = Dog`,
+		`Syntax Error GraphQL (1:8) Expected "type", found Name "union"`,
+		false,
+	})
+}
+
 func TestSchemaParser_SimpleNonNullType(t *testing.T) {
 
 	body := `
@@ -717,6 +884,54 @@ func TestSchemaParser_UnionWithTwoTypes(t *testing.T) {
 	}
 }
 
+func TestSchemaParser_UnionWithLeadingPipe(t *testing.T) {
+	body := `union Hello = | Wo | Rld`
+	astDoc := parse(t, body)
+	expected := ast.NewDocument(&ast.Document{
+		Loc: testLoc(0, 24),
+		Definitions: []ast.Node{
+			ast.NewUnionDefinition(&ast.UnionDefinition{
+				Loc: testLoc(0, 24),
+				Name: ast.NewName(&ast.Name{
+					Value: "Hello",
+					Loc:   testLoc(6, 11),
+				}),
+				Directives: []*ast.Directive{},
+				Types: []*ast.Named{
+					ast.NewNamed(&ast.Named{
+						Loc: testLoc(16, 18),
+						Name: ast.NewName(&ast.Name{
+							Value: "Wo",
+							Loc:   testLoc(16, 18),
+						}),
+					}),
+					ast.NewNamed(&ast.Named{
+						Loc: testLoc(21, 24),
+						Name: ast.NewName(&ast.Name{
+							Value: "Rld",
+							Loc:   testLoc(21, 24),
+						}),
+					}),
+				},
+			}),
+		},
+	})
+	if !reflect.DeepEqual(astDoc, expected) {
+		t.Fatalf("unexpected document, expected: %v, got: %v", expected, astDoc)
+	}
+}
+
+func TestSchemaParser_UnionDoesNotAllowEmptyOrDoublePipes(t *testing.T) {
+	tests := []errorMessageTest{
+		{`union Hello = |`, `Syntax Error GraphQL (1:16) Expected Name, found EOF`, false},
+		{`union Hello = || Wo`, `Syntax Error GraphQL (1:16) Expected Name, found |`, false},
+		{`union Hello = Wo |`, `Syntax Error GraphQL (1:19) Expected Name, found EOF`, false},
+	}
+	for _, test := range tests {
+		testErrorMessage(t, test)
+	}
+}
+
 func TestSchemaParser_Scalar(t *testing.T) {
 	body := `scalar Hello`
 	astDoc := parse(t, body)
@@ -738,6 +953,48 @@ func TestSchemaParser_Scalar(t *testing.T) {
 	}
 }
 
+func TestSchemaParser_ScalarWithDirective(t *testing.T) {
+	body := `scalar JSON @specifiedBy(url: "https://json.org")`
+	astDoc := parse(t, body)
+	expected := ast.NewDocument(&ast.Document{
+		Loc: testLoc(0, 49),
+		Definitions: []ast.Node{
+			ast.NewScalarDefinition(&ast.ScalarDefinition{
+				Loc: testLoc(0, 49),
+				Name: ast.NewName(&ast.Name{
+					Value: "JSON",
+					Loc:   testLoc(7, 11),
+				}),
+				Directives: []*ast.Directive{
+					ast.NewDirective(&ast.Directive{
+						Loc: testLoc(12, 49),
+						Name: ast.NewName(&ast.Name{
+							Value: "specifiedBy",
+							Loc:   testLoc(13, 24),
+						}),
+						Arguments: []*ast.Argument{
+							ast.NewArgument(&ast.Argument{
+								Loc: testLoc(25, 48),
+								Name: ast.NewName(&ast.Name{
+									Value: "url",
+									Loc:   testLoc(25, 28),
+								}),
+								Value: ast.NewStringValue(&ast.StringValue{
+									Value: "https://json.org",
+									Loc:   testLoc(30, 48),
+								}),
+							}),
+						},
+					}),
+				},
+			}),
+		},
+	})
+	if !reflect.DeepEqual(astDoc, expected) {
+		t.Fatalf("unexpected document, expected: %v, got: %v", expected, astDoc)
+	}
+}
+
 func TestSchemaParser_SimpleInputObject(t *testing.T) {
 	body := `
 input Hello {
@@ -829,3 +1086,410 @@ input Hello {
 		t.Fatalf("unexpected document, expected: %v, got: %v", expectedError, err)
 	}
 }
+
+func TestSchemaParser_FieldDefinitions(t *testing.T) {
+	body := `
+type Query {
+  version: String
+  users(first: Int = 10, after: String, order: Order = ASC): [User!]!
+}`
+	astDoc := parse(t, body)
+	fields := astDoc.Definitions[0].(*ast.ObjectDefinition).Fields
+	if len(fields) != 2 {
+		t.Fatalf("expected two fields, got: %v", fields)
+	}
+
+	version := fields[0]
+	if version.Name.Value != "version" || len(version.Arguments) != 0 || ast.TypeString(version.Type) != "String" {
+		t.Fatalf("unexpected field without arguments: %v", version)
+	}
+
+	users := fields[1]
+	if users.Name.Value != "users" || ast.TypeString(users.Type) != "[User!]!" {
+		t.Fatalf("unexpected field: %v", users)
+	}
+	if _, ok := users.Type.(*ast.NonNull).Type.(*ast.List); !ok {
+		t.Fatalf("expected a non-null list return type, got: %v", users.Type)
+	}
+	expectedArgs := []struct {
+		name         string
+		ttype        string
+		defaultValue interface{}
+	}{
+		{"first", "Int", "10"},
+		{"after", "String", nil},
+		{"order", "Order", "ASC"},
+	}
+	if len(users.Arguments) != len(expectedArgs) {
+		t.Fatalf("expected %d arguments, got: %v", len(expectedArgs), users.Arguments)
+	}
+	for i, expected := range expectedArgs {
+		arg := users.Arguments[i]
+		if arg.Name.Value != expected.name || ast.TypeString(arg.Type) != expected.ttype {
+			t.Fatalf("expected argument %v: %v, got: %v: %v", expected.name, expected.ttype, arg.Name.Value, ast.TypeString(arg.Type))
+		}
+		var defaultValue interface{}
+		if arg.DefaultValue != nil {
+			defaultValue = arg.DefaultValue.GetValue()
+		}
+		if defaultValue != expected.defaultValue {
+			t.Fatalf("expected default %v for %v, got: %v", expected.defaultValue, expected.name, defaultValue)
+		}
+	}
+	if !reflect.DeepEqual(users.Loc, testLoc(34, 101)) {
+		t.Fatalf("unexpected field location: %v", users.Loc)
+	}
+}
+
+func TestSchemaParser_InputValueDefinitions(t *testing.T) {
+	body := `
+input Search {
+  limit: Int = 10
+  id: ID!
+  tags: [String] @deprecated
+}`
+	astDoc := parse(t, body)
+	fields := astDoc.Definitions[0].(*ast.InputObjectDefinition).Fields
+	expected := []struct {
+		name         string
+		ttype        string
+		defaultValue interface{}
+		directives   []string
+	}{
+		{"limit", "Int", "10", []string{}},
+		{"id", "ID!", nil, []string{}},
+		{"tags", "[String]", nil, []string{"deprecated"}},
+	}
+	if len(fields) != len(expected) {
+		t.Fatalf("expected %d input values, got: %v", len(expected), fields)
+	}
+	for i, test := range expected {
+		field := fields[i]
+		if field.Name.Value != test.name || ast.TypeString(field.Type) != test.ttype {
+			t.Fatalf("expected %v: %v, got: %v: %v", test.name, test.ttype, field.Name.Value, ast.TypeString(field.Type))
+		}
+		var defaultValue interface{}
+		if field.DefaultValue != nil {
+			defaultValue = field.DefaultValue.GetValue()
+		}
+		if defaultValue != test.defaultValue {
+			t.Fatalf("expected default %v for %v, got: %v", test.defaultValue, test.name, defaultValue)
+		}
+		directives := []string{}
+		for _, directive := range field.Directives {
+			directives = append(directives, directive.Name.Value)
+		}
+		if !reflect.DeepEqual(directives, test.directives) {
+			t.Fatalf("expected directives %v for %v, got: %v", test.directives, test.name, directives)
+		}
+	}
+
+	_, err := Parse(ParseParams{Source: `input Search { limit: Int = $max }`})
+	checkErrorMessage(t, err, `Syntax Error GraphQL (1:29) Unexpected $, variables are not allowed in constant positions`)
+}
+
+func TestSchemaParser_InterfaceWithFieldsAndDirectives(t *testing.T) {
+	body := `
+interface Node @key(fields: "id") {
+  id: ID!
+  createdAt(format: String = "iso"): String
+}`
+	astDoc := parse(t, body)
+	iface, ok := astDoc.Definitions[0].(*ast.InterfaceDefinition)
+	if !ok {
+		t.Fatalf("expected an interface definition, got: %v", astDoc.Definitions[0])
+	}
+	if iface.Name.Value != "Node" || !reflect.DeepEqual(iface.Loc, testLoc(1, 92)) {
+		t.Fatalf("unexpected interface: %v at %v", iface.Name, iface.Loc)
+	}
+	if len(iface.Directives) != 1 || iface.Directives[0].Name.Value != "key" || len(iface.Directives[0].Arguments) != 1 {
+		t.Fatalf("unexpected directives: %v", iface.Directives)
+	}
+	fields := []string{}
+	for _, field := range iface.Fields {
+		fields = append(fields, field.Name.Value+": "+ast.TypeString(field.Type))
+	}
+	if expected := []string{"id: ID!", "createdAt: String"}; !reflect.DeepEqual(fields, expected) {
+		t.Fatalf("expected fields %v, got: %v", expected, fields)
+	}
+	if len(iface.Fields[1].Arguments) != 1 || iface.Fields[1].Arguments[0].DefaultValue.GetValue() != "iso" {
+		t.Fatalf("unexpected field arguments: %v", iface.Fields[1].Arguments)
+	}
+}
+
+func TestSchemaParser_EnumWithDeprecatedValue(t *testing.T) {
+	body := `
+enum Episode {
+  NEWHOPE
+  EMPIRE
+  JEDI @deprecated(reason: "Spoilers")
+}`
+	astDoc := parse(t, body)
+	enum := astDoc.Definitions[0].(*ast.EnumDefinition)
+	values := []string{}
+	for _, value := range enum.Values {
+		values = append(values, value.Name.Value)
+	}
+	if expected := []string{"NEWHOPE", "EMPIRE", "JEDI"}; !reflect.DeepEqual(values, expected) {
+		t.Fatalf("expected values %v, got: %v", expected, values)
+	}
+	jedi := enum.Values[2]
+	if len(jedi.Directives) != 1 || jedi.Directives[0].Name.Value != "deprecated" {
+		t.Fatalf("unexpected directives: %v", jedi.Directives)
+	}
+	if !reflect.DeepEqual(jedi.Loc, testLoc(37, 73)) {
+		t.Fatalf("unexpected enum value location: %v", jedi.Loc)
+	}
+}
+
+func TestSchemaParser_EnumDoesNotAllowReservedValues(t *testing.T) {
+	tests := []errorMessageTest{
+		{`enum Bool { YES true }`, `Syntax Error GraphQL (1:17) Unexpected Name "true"`, false},
+		{`enum Bool { false NO }`, `Syntax Error GraphQL (1:13) Unexpected Name "false"`, false},
+		{`enum Maybe { null }`, `Syntax Error GraphQL (1:14) Unexpected Name "null"`, false},
+	}
+	for _, test := range tests {
+		testErrorMessage(t, test)
+	}
+	// names merely starting with a reserved word are fine
+	parse(t, `enum Maybe { nullable trueish falsey }`)
+}
+
+func TestSchemaParser_InputObjectWithDefaultsAndNestedInput(t *testing.T) {
+	body := `
+input ReviewInput {
+  stars: Int! = 5
+  comment: String
+  author: AuthorInput = {name: "anonymous"}
+  tags: [TagInput!]
+}`
+	astDoc := parse(t, body)
+	input := astDoc.Definitions[0].(*ast.InputObjectDefinition)
+	if input.Name.Value != "ReviewInput" {
+		t.Fatalf("unexpected input name: %v", input.Name)
+	}
+	expected := []struct {
+		name        string
+		ttype       string
+		defaultKind string
+	}{
+		{"stars", "Int!", kinds.IntValue},
+		{"comment", "String", ""},
+		{"author", "AuthorInput", kinds.ObjectValue},
+		{"tags", "[TagInput!]", ""},
+	}
+	if len(input.Fields) != len(expected) {
+		t.Fatalf("expected %d fields, got: %v", len(expected), input.Fields)
+	}
+	for i, test := range expected {
+		field := input.Fields[i]
+		if field.Name.Value != test.name || ast.TypeString(field.Type) != test.ttype {
+			t.Fatalf("expected %v: %v, got: %v: %v", test.name, test.ttype, field.Name.Value, ast.TypeString(field.Type))
+		}
+		defaultKind := ""
+		if field.DefaultValue != nil {
+			defaultKind = field.DefaultValue.GetKind()
+		}
+		if defaultKind != test.defaultKind {
+			t.Fatalf("expected default of kind %q for %v, got: %q", test.defaultKind, test.name, defaultKind)
+		}
+	}
+}
+
+func TestSchemaParser_SchemaDefinitions(t *testing.T) {
+	tests := []struct {
+		body       string
+		operations []string
+	}{
+		{
+			`schema { query: Query mutation: Mutation subscription: Subscription }`,
+			[]string{"query: Query", "mutation: Mutation", "subscription: Subscription"},
+		},
+		{
+			`schema @public { query: Root }`,
+			[]string{"query: Root"},
+		},
+	}
+	for _, test := range tests {
+		astDoc := parse(t, test.body)
+		schema, ok := astDoc.Definitions[0].(*ast.SchemaDefinition)
+		if !ok {
+			t.Fatalf("expected a schema definition for %q, got: %v", test.body, astDoc.Definitions[0])
+		}
+		operations := []string{}
+		for _, operationType := range schema.OperationTypes {
+			operations = append(operations, operationType.Operation+": "+operationType.Type.Name.Value)
+		}
+		if !reflect.DeepEqual(operations, test.operations) {
+			t.Fatalf("expected operation types %v, got: %v", test.operations, operations)
+		}
+		if !reflect.DeepEqual(schema.Loc, testLoc(0, len(test.body))) {
+			t.Fatalf("unexpected schema location: %v", schema.Loc)
+		}
+	}
+	if schema := parse(t, tests[1].body).Definitions[0].(*ast.SchemaDefinition); len(schema.Directives) != 1 {
+		t.Fatalf("expected one schema directive, got: %v", schema.Directives)
+	}
+}
+
+func TestSchemaParser_DirectiveDefinitions(t *testing.T) {
+	tests := []struct {
+		body      string
+		args      []string
+		locations []string
+	}{
+		{
+			`directive @example on FIELD`,
+			[]string{},
+			[]string{"FIELD"},
+		},
+		{
+			`directive @example(arg: Int, other: String! = "x") on FIELD | QUERY | FIELD_DEFINITION`,
+			[]string{"arg: Int", "other: String!"},
+			[]string{"FIELD", "QUERY", "FIELD_DEFINITION"},
+		},
+	}
+	for _, test := range tests {
+		astDoc := parse(t, test.body)
+		def, ok := astDoc.Definitions[0].(*ast.DirectiveDefinition)
+		if !ok {
+			t.Fatalf("expected a directive definition for %q, got: %v", test.body, astDoc.Definitions[0])
+		}
+		if def.Name.Value != "example" {
+			t.Fatalf("unexpected directive name: %v", def.Name)
+		}
+		args := []string{}
+		for _, arg := range def.Arguments {
+			args = append(args, arg.Name.Value+": "+ast.TypeString(arg.Type))
+		}
+		if !reflect.DeepEqual(args, test.args) {
+			t.Fatalf("expected arguments %v, got: %v", test.args, args)
+		}
+		locations := []string{}
+		for _, location := range def.Locations {
+			locations = append(locations, location.Value)
+		}
+		if !reflect.DeepEqual(locations, test.locations) {
+			t.Fatalf("expected locations %v, got: %v", test.locations, locations)
+		}
+		if !reflect.DeepEqual(def.Loc, testLoc(0, len(test.body))) {
+			t.Fatalf("unexpected directive definition location: %v", def.Loc)
+		}
+	}
+}
+
+func TestSchemaParser_DirectiveDefinitionRejectsUnknownLocations(t *testing.T) {
+	tests := []errorMessageTest{
+		{`directive @example on FOO`, `Syntax Error GraphQL (1:23) Unexpected Name "FOO"`, false},
+		{`directive @example on FIELD | field`, `Syntax Error GraphQL (1:31) Unexpected Name "field"`, false},
+		{`directive @example on`, `Syntax Error GraphQL (1:22) Expected Name, found EOF`, false},
+	}
+	for _, test := range tests {
+		testErrorMessage(t, test)
+	}
+}
+
+func TestSchemaParser_CapturesDescriptions(t *testing.T) {
+	body := `
+"""
+The user
+"""
+type User {
+  "The user id"
+  id: ID!
+  """
+  Friends of the user,
+  most recent first.
+  """
+  friends("How many to return" first: Int): [User]
+}
+"A single-line type description"
+type Query {
+  me: User
+}`
+	astDoc := parse(t, body)
+	user := astDoc.Definitions[0].(*ast.ObjectDefinition)
+	query := astDoc.Definitions[1].(*ast.ObjectDefinition)
+	tests := []struct {
+		description *ast.StringValue
+		value       string
+	}{
+		{user.Description, "The user"},
+		{user.Fields[0].Description, "The user id"},
+		{user.Fields[1].Description, "Friends of the user,\nmost recent first."},
+		{user.Fields[1].Arguments[0].Description, "How many to return"},
+		{query.Description, "A single-line type description"},
+	}
+	for _, test := range tests {
+		if test.description == nil {
+			t.Fatalf("expected description %q, got none", test.value)
+		}
+		if test.description.Kind != kinds.StringValue || test.description.Value != test.value {
+			t.Fatalf("expected description %q, got: %v %q", test.value, test.description.Kind, test.description.Value)
+		}
+	}
+	if query.Fields[0].Description != nil {
+		t.Fatalf("expected no description on Query.me, got: %v", query.Fields[0].Description)
+	}
+}
+
+func TestSchemaParser_ImplementsInterfaces(t *testing.T) {
+	tests := []struct {
+		body       string
+		interfaces []string
+	}{
+		{`type User implements Node { id: ID }`, []string{"Node"}},
+		{`type User implements Node & Entity @key { id: ID }`, []string{"Node", "Entity"}},
+		{`type User implements & Node & Entity & Timestamped { id: ID }`, []string{"Node", "Entity", "Timestamped"}},
+		{`type User @key { id: ID }`, []string{}},
+	}
+	for _, test := range tests {
+		astDoc := parse(t, test.body)
+		object := astDoc.Definitions[0].(*ast.ObjectDefinition)
+		interfaces := []string{}
+		for _, named := range object.Interfaces {
+			interfaces = append(interfaces, named.Name.Value)
+		}
+		if !reflect.DeepEqual(interfaces, test.interfaces) {
+			t.Fatalf("expected interfaces %v for %q, got: %v", test.interfaces, test.body, interfaces)
+		}
+	}
+
+	errors := []errorMessageTest{
+		{`type User implements { id: ID }`, `Syntax Error GraphQL (1:22) Expected Name, found {`, false},
+		{`type User implements Node & { id: ID }`, `Syntax Error GraphQL (1:29) Expected Name, found {`, false},
+		{`type User implements Node && Entity { id: ID }`, `Syntax Error GraphQL (1:28) Expected Name, found &`, false},
+	}
+	for _, test := range errors {
+		testErrorMessage(t, test)
+	}
+}
+
+func TestSchemaParser_RepeatableDirectiveDefinitions(t *testing.T) {
+	tests := []struct {
+		body       string
+		repeatable bool
+	}{
+		{`directive @tag(name: String!) repeatable on FIELD_DEFINITION | OBJECT`, true},
+		{`directive @tag repeatable on FIELD`, true},
+		{`directive @tag(name: String!) on FIELD`, false},
+	}
+	for _, test := range tests {
+		def := parse(t, test.body).Definitions[0].(*ast.DirectiveDefinition)
+		if def.Repeatable != test.repeatable {
+			t.Fatalf("expected repeatable %v for %q, got: %v", test.repeatable, test.body, def.Repeatable)
+		}
+		if !reflect.DeepEqual(def.Loc, testLoc(0, len(test.body))) {
+			t.Fatalf("unexpected directive definition location: %v", def.Loc)
+		}
+	}
+
+	errors := []errorMessageTest{
+		{`directive @tag on FIELD repeatable`, `Syntax Error GraphQL (1:25) Unexpected Name "repeatable", it must come before "on"`, false},
+		{`directive @tag on repeatable FIELD`, `Syntax Error GraphQL (1:19) Unexpected Name "repeatable"`, false},
+		{`directive @tag repeatable(name: String) on FIELD`, `Syntax Error GraphQL (1:26) Expected "on", found (`, false},
+	}
+	for _, test := range errors {
+		testErrorMessage(t, test)
+	}
+}
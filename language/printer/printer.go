@@ -2,11 +2,14 @@ package printer
 
 import (
 	"fmt"
+	"io"
 	"strings"
 
 	"reflect"
 
 	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/lexer"
+	"github.com/graphql-go/graphql/language/source"
 	"github.com/graphql-go/graphql/language/visitor"
 )
 
@@ -131,6 +134,46 @@ func indent(maybeString interface{}) string {
 	return ""
 }
 
+// Given a description node, print it as a regular string when it fits on one
+// line without quotes or backslashes, otherwise as an unindented block string.
+// Descriptions holding characters a block string cannot carry, such as `\r`,
+// are printed as escaped regular strings instead.
+func description(maybeDescription interface{}) string {
+	value := ""
+	switch desc := maybeDescription.(type) {
+	case *ast.StringValue:
+		if desc != nil {
+			value = desc.Value
+		}
+	case map[string]interface{}:
+		value = getMapValueString(desc, "Value")
+	}
+	if value == "" {
+		return ""
+	}
+	if !strings.ContainsAny(value, "\n\"\\") || strings.IndexFunc(value, isBlockStringUnsafe) >= 0 {
+		return quoteString(value)
+	}
+	return `"""` + "\n" + strings.Replace(value, `"""`, `\"""`, -1) + "\n" + `"""`
+}
+
+// isBlockStringUnsafe reports whether r would not survive a block string, i.e.
+// is a control character other than a newline or tab.
+func isBlockStringUnsafe(r rune) bool {
+	return r < 0x20 && r != '\n' && r != '\t'
+}
+
+// Given printed arguments, wrap them in parentheses on one line, or one per
+// line when any of them spans several lines, e.g. because of a description.
+func arguments(args []string) string {
+	for _, arg := range args {
+		if strings.Contains(arg, "\n") {
+			return wrap("(", indent("\n"+join(args, "\n")), "\n)")
+		}
+	}
+	return wrap("(", join(args, ", "), ")")
+}
+
 var printDocASTReducer = map[string]visitor.VisitFunc{
 	"Name": func(p visitor.VisitFuncParams) (string, interface{}) {
 		switch node := p.Node.(type) {
@@ -352,9 +395,9 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 	"StringValue": func(p visitor.VisitFuncParams) (string, interface{}) {
 		switch node := p.Node.(type) {
 		case *ast.StringValue:
-			return visitor.ActionUpdate, `"` + fmt.Sprintf("%v", node.Value) + `"`
+			return visitor.ActionUpdate, quoteString(node.Value)
 		case map[string]interface{}:
-			return visitor.ActionUpdate, `"` + getMapValueString(node, "Value") + `"`
+			return visitor.ActionUpdate, quoteString(getMapValueString(node, "Value"))
 		}
 		return visitor.ActionNoChange, nil
 	},
@@ -367,6 +410,9 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 		}
 		return visitor.ActionNoChange, nil
 	},
+	"NullValue": func(p visitor.VisitFuncParams) (string, interface{}) {
+		return visitor.ActionUpdate, "null"
+	},
 	"EnumValue": func(p visitor.VisitFuncParams) (string, interface{}) {
 		switch node := p.Node.(type) {
 		case *ast.EnumValue:
@@ -506,6 +552,7 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 				fmt.Sprintf("%v", node.Name),
 				join(directives, " "),
 			}, " ")
+			str = join([]string{description(node.Description), str}, "\n")
 			return visitor.ActionUpdate, str
 		case map[string]interface{}:
 			name := getMapValueString(node, "Name")
@@ -518,6 +565,7 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 				name,
 				join(directives, " "),
 			}, " ")
+			str = join([]string{description(node["Description"]), str}, "\n")
 			return visitor.ActionUpdate, str
 		}
 		return visitor.ActionNoChange, nil
@@ -539,6 +587,7 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 				join(directives, " "),
 				block(fields),
 			}, " ")
+			str = join([]string{description(node.Description), str}, "\n")
 			return visitor.ActionUpdate, str
 		case map[string]interface{}:
 			name := getMapValueString(node, "Name")
@@ -555,6 +604,7 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 				join(directives, " "),
 				block(fields),
 			}, " ")
+			str = join([]string{description(node["Description"]), str}, "\n")
 			return visitor.ActionUpdate, str
 		}
 		return visitor.ActionNoChange, nil
@@ -569,7 +619,8 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 			for _, directive := range node.Directives {
 				directives = append(directives, fmt.Sprintf("%v", directive.Name))
 			}
-			str := name + wrap("(", join(args, ", "), ")") + ": " + ttype + wrap(" ", join(directives, " "), "")
+			str := name + arguments(args) + ": " + ttype + wrap(" ", join(directives, " "), "")
+			str = join([]string{description(node.Description), str}, "\n")
 			return visitor.ActionUpdate, str
 		case map[string]interface{}:
 			name := getMapValueString(node, "Name")
@@ -579,7 +630,8 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 			for _, directive := range getMapSliceValue(node, "Directives") {
 				directives = append(directives, fmt.Sprintf("%v", directive))
 			}
-			str := name + wrap("(", join(args, ", "), ")") + ": " + ttype + wrap(" ", join(directives, " "), "")
+			str := name + arguments(args) + ": " + ttype + wrap(" ", join(directives, " "), "")
+			str = join([]string{description(node["Description"]), str}, "\n")
 			return visitor.ActionUpdate, str
 		}
 		return visitor.ActionNoChange, nil
@@ -600,6 +652,7 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 				join(directives, " "),
 			}, " ")
 
+			str = join([]string{description(node.Description), str}, "\n")
 			return visitor.ActionUpdate, str
 		case map[string]interface{}:
 			name := getMapValueString(node, "Name")
@@ -614,6 +667,7 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 				wrap("= ", defaultValue, ""),
 				join(directives, " "),
 			}, " ")
+			str = join([]string{description(node["Description"]), str}, "\n")
 			return visitor.ActionUpdate, str
 		}
 		return visitor.ActionNoChange, nil
@@ -633,6 +687,7 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 				join(directives, " "),
 				block(fields),
 			}, " ")
+			str = join([]string{description(node.Description), str}, "\n")
 			return visitor.ActionUpdate, str
 		case map[string]interface{}:
 			name := getMapValueString(node, "Name")
@@ -647,6 +702,7 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 				join(directives, " "),
 				block(fields),
 			}, " ")
+			str = join([]string{description(node["Description"]), str}, "\n")
 			return visitor.ActionUpdate, str
 		}
 		return visitor.ActionNoChange, nil
@@ -666,6 +722,7 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 				join(directives, " "),
 				"= " + join(types, " | "),
 			}, " ")
+			str = join([]string{description(node.Description), str}, "\n")
 			return visitor.ActionUpdate, str
 		case map[string]interface{}:
 			name := getMapValueString(node, "Name")
@@ -680,6 +737,7 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 				join(directives, " "),
 				"= " + join(types, " | "),
 			}, " ")
+			str = join([]string{description(node["Description"]), str}, "\n")
 			return visitor.ActionUpdate, str
 		}
 		return visitor.ActionNoChange, nil
@@ -699,6 +757,7 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 				join(directives, " "),
 				block(values),
 			}, " ")
+			str = join([]string{description(node.Description), str}, "\n")
 			return visitor.ActionUpdate, str
 		case map[string]interface{}:
 			name := getMapValueString(node, "Name")
@@ -713,6 +772,7 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 				join(directives, " "),
 				block(values),
 			}, " ")
+			str = join([]string{description(node["Description"]), str}, "\n")
 			return visitor.ActionUpdate, str
 		}
 		return visitor.ActionNoChange, nil
@@ -729,6 +789,7 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 				name,
 				join(directives, " "),
 			}, " ")
+			str = join([]string{description(node.Description), str}, "\n")
 			return visitor.ActionUpdate, str
 		case map[string]interface{}:
 			name := getMapValueString(node, "Name")
@@ -740,6 +801,7 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 				name,
 				join(directives, " "),
 			}, " ")
+			str = join([]string{description(node["Description"]), str}, "\n")
 			return visitor.ActionUpdate, str
 		}
 		return visitor.ActionNoChange, nil
@@ -759,6 +821,7 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 				join(directives, " "),
 				block(fields),
 			}, " ")
+			str = join([]string{description(node.Description), str}, "\n")
 			return visitor.ActionUpdate, str
 		case map[string]interface{}:
 			name := getMapValueString(node, "Name")
@@ -773,6 +836,7 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 				join(directives, " "),
 				block(fields),
 			}, " ")
+			str = join([]string{description(node["Description"]), str}, "\n")
 			return visitor.ActionUpdate, str
 		}
 		return visitor.ActionNoChange, nil
@@ -790,18 +854,39 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 		}
 		return visitor.ActionNoChange, nil
 	},
+	"InterfaceExtensionDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
+		switch node := p.Node.(type) {
+		case *ast.InterfaceExtensionDefinition:
+			definition := fmt.Sprintf("%v", node.Definition)
+			str := "extend " + definition
+			return visitor.ActionUpdate, str
+		case map[string]interface{}:
+			definition := getMapValueString(node, "Definition")
+			str := "extend " + definition
+			return visitor.ActionUpdate, str
+		}
+		return visitor.ActionNoChange, nil
+	},
 	"DirectiveDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
 		switch node := p.Node.(type) {
 		case *ast.DirectiveDefinition:
-			args := wrap("(", join(toSliceString(node.Arguments), ", "), ")")
+			args := arguments(toSliceString(node.Arguments))
+			if node.Repeatable {
+				args += " repeatable"
+			}
 			str := fmt.Sprintf("directive @%v%v on %v", node.Name, args, join(toSliceString(node.Locations), " | "))
+			str = join([]string{description(node.Description), str}, "\n")
 			return visitor.ActionUpdate, str
 		case map[string]interface{}:
 			name := getMapValueString(node, "Name")
 			locations := toSliceString(getMapValue(node, "Locations"))
 			args := toSliceString(getMapValue(node, "Arguments"))
-			argsStr := wrap("(", join(args, ", "), ")")
+			argsStr := arguments(args)
+			if repeatable, _ := getMapValue(node, "Repeatable").(bool); repeatable {
+				argsStr += " repeatable"
+			}
 			str := fmt.Sprintf("directive @%v%v on %v", name, argsStr, join(locations, " | "))
+			str = join([]string{description(node["Description"]), str}, "\n")
 			return visitor.ActionUpdate, str
 		}
 		return visitor.ActionNoChange, nil
@@ -820,3 +905,126 @@ func Print(astNode ast.Node) (printed interface{}) {
 	}, nil)
 	return printed
 }
+
+// Fprint writes node to w as Print renders it. Documents are written one
+// definition at a time, so the whole document is never built as one string.
+// The first error returned by w is returned.
+func Fprint(w io.Writer, node ast.Node) error {
+	doc, ok := node.(*ast.Document)
+	if !ok || doc == nil {
+		_, err := fmt.Fprintf(w, "%v", Print(node))
+		return err
+	}
+	separator := ""
+	for _, def := range doc.Definitions {
+		printed := fmt.Sprintf("%v", Print(def))
+		if printed == "" {
+			continue
+		}
+		if _, err := io.WriteString(w, separator); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, printed); err != nil {
+			return err
+		}
+		separator = "\n\n"
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// PrintCompact renders node like Print but on a single line, keeping only the
+// whitespace needed to separate adjacent names and numbers, e.g.
+// `query Q($id:ID){user(id:$id){name}}`, for sending over the wire. Block
+// strings are downgraded to regular strings. If the printed node cannot be
+// tokenized, it is returned as Print renders it.
+func PrintCompact(node ast.Node) string {
+	printed := fmt.Sprintf("%v", Print(node))
+	lex := lexer.Lex(source.NewSource(&source.Source{Body: []byte(printed)}))
+	compact := []string{}
+	prev := lexer.Token{}
+	for {
+		token, err := lex(0)
+		if err != nil {
+			return printed
+		}
+		if token.Kind == lexer.EOF {
+			break
+		}
+		if isWordToken(prev) && isWordToken(token) {
+			compact = append(compact, " ")
+		}
+		switch token.Kind {
+		case lexer.NAME, lexer.INT, lexer.FLOAT:
+			compact = append(compact, token.Value)
+		case lexer.STRING, lexer.BLOCK_STRING:
+			compact = append(compact, quoteString(token.Value))
+		default:
+			compact = append(compact, token.Kind.String())
+		}
+		prev = token
+	}
+	return strings.Join(compact, "")
+}
+
+// isWordToken reports whether a token needs whitespace to be told apart from
+// a neighbouring token of the same sort, e.g. `query Q` or `[1 2]`.
+func isWordToken(token lexer.Token) bool {
+	return token.Kind == lexer.NAME || token.Kind == lexer.INT || token.Kind == lexer.FLOAT
+}
+
+// quoteString renders a string value as a regular string literal, escaping
+// quotes, backslashes and control characters.
+func quoteString(value string) string {
+	quoted := []string{`"`}
+	for _, r := range value {
+		switch r {
+		case '"':
+			quoted = append(quoted, `\"`)
+		case '\\':
+			quoted = append(quoted, `\\`)
+		case '\n':
+			quoted = append(quoted, `\n`)
+		case '\r':
+			quoted = append(quoted, `\r`)
+		case '\t':
+			quoted = append(quoted, `\t`)
+		default:
+			if r < 0x20 {
+				quoted = append(quoted, fmt.Sprintf(`\u%04X`, r))
+			} else {
+				quoted = append(quoted, string(r))
+			}
+		}
+	}
+	return strings.Join(append(quoted, `"`), "")
+}
+
+// PrintArguments renders an argument list in parentheses, e.g. `(id: 1, limit: 10)`.
+// An empty list renders as the empty string.
+func PrintArguments(args []*ast.Argument) string {
+	printed := []string{}
+	for _, arg := range args {
+		printed = append(printed, fmt.Sprintf("%v", Print(arg)))
+	}
+	return wrap("(", join(printed, ", "), ")")
+}
+
+// PrintValue renders a value literal, e.g. `{x: 1, y: [1, 2]}`. List items and
+// object fields are separated by a comma and a space. A nil value renders as
+// the empty string.
+func PrintValue(value ast.Value) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", Print(value))
+}
+
+// PrintDefinition renders a single definition of a document, e.g. the
+// operation being executed, without the rest of the document.
+func PrintDefinition(def ast.Definition) string {
+	if def == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", Print(def))
+}
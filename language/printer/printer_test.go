@@ -1,8 +1,11 @@
 package printer_test
 
 import (
+	"bytes"
+	"errors"
 	"io/ioutil"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/graphql-go/graphql/language/ast"
@@ -59,6 +62,26 @@ func TestPrinter_PrintsMinimalAST(t *testing.T) {
 	}
 }
 
+func TestPrinter_PrintsArguments(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{`{ field }`, ``},
+		{`{ field(id: 1) }`, `(id: 1)`},
+		{`query Q($limit: Int) { field(id: 1, limit: $limit, tags: ["a", "b"]) }`, `(id: 1, limit: $limit, tags: ["a", "b"])`},
+		{`{ field(id: null, tags: [null]) }`, `(id: null, tags: [null])`},
+	}
+	for _, test := range tests {
+		astDoc := parse(t, test.query)
+		op := astDoc.Definitions[0].(*ast.OperationDefinition)
+		field := op.SelectionSet.Selections[0].(*ast.Field)
+		if results := printer.PrintArguments(field.Arguments); results != test.expected {
+			t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(test.expected, results))
+		}
+	}
+}
+
 // TestPrinter_ProducesHelpfulErrorMessages
 // Skipped, can't figure out how to pass in an invalid astDoc, which is already strongly-typed
 
@@ -186,3 +209,249 @@ fragment frag on Follower {
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, results))
 	}
 }
+
+func TestPrinter_PrintsDirectivesInAllPositions(t *testing.T) {
+	query := `query Q($id: ID) @live {
+  user(id: $id) @include(if: true) {
+    ...F @skip(if: false)
+    ... on User @defer(label: "u") {
+      name
+    }
+    ... @defer {
+      id
+    }
+  }
+}
+
+fragment F on User @beta {
+  email
+}
+
+schema @schemaDirective {
+  query: Query
+}
+
+scalar Date @specifiedBy(url: "x")
+
+type User implements Node @key(fields: "id") {
+  id: ID! @external
+  posts(first: Int = 1 @arg): [Post] @deprecated(reason: "no")
+}
+
+interface Node @i {
+  id: ID!
+}
+
+union SearchResult @u = User | Post
+
+enum Color @e {
+  RED @deprecated
+}
+
+input Filter @input {
+  name: String @field
+}
+
+extend type User @ext {
+  age: Int
+}
+`
+	results := printer.Print(parse(t, query))
+	if !reflect.DeepEqual(query, results) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(query, results))
+	}
+}
+
+func TestPrinter_PrintsSingleDefinitions(t *testing.T) {
+	astDoc := parse(t, `
+query Q($id: ID!) { user(id: $id) { ...UserFields } }
+fragment UserFields on User @cached { id, name }
+`)
+	tests := []struct {
+		def      ast.Definition
+		expected string
+	}{
+		{
+			astDoc.Definitions[0].(ast.Definition),
+			`query Q($id: ID!) {
+  user(id: $id) {
+    ...UserFields
+  }
+}`,
+		},
+		{
+			astDoc.Definitions[1].(ast.Definition),
+			`fragment UserFields on User @cached {
+  id
+  name
+}`,
+		},
+	}
+	for _, test := range tests {
+		results := printer.PrintDefinition(test.def)
+		if results != test.expected {
+			t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(test.expected, results))
+		}
+	}
+	if results := printer.PrintDefinition(nil); results != "" {
+		t.Fatalf("expected nil definition to print as empty string, got: %q", results)
+	}
+}
+
+func TestPrinter_RoundTripsDocuments(t *testing.T) {
+	b, err := ioutil.ReadFile("../../kitchen-sink.graphql")
+	if err != nil {
+		t.Fatalf("unable to load kitchen-sink.graphql")
+	}
+	tests := []string{
+		string(b),
+		`query Q($id: ID! = "4", $ids: [Int!] = [1, 2], $filter: Filter = {name: "x", tags: [A, B], nested: {deep: null}}) {
+  alias: user(id: $id, flag: true, ratio: -1.5e3) @include(if: $flag) {
+    ...UserFields @skip(if: false)
+    ... on Admin { permissions }
+    ... @defer { slow }
+  }
+}
+fragment UserFields on User { id, name, friends(first: 10) { edges { node { id } } } }`,
+	}
+	for _, query := range tests {
+		astDoc := parse(t, query)
+		printed, ok := printer.Print(astDoc).(string)
+		if !ok {
+			t.Fatalf("expected printer to produce a string, got: %v", printed)
+		}
+		if reparsed := parse(t, printed); !reflect.DeepEqual(astDoc, reparsed) {
+			t.Fatalf("reparsed document differs from the original, printed as:\n%v", printed)
+		}
+		if reprinted := printer.Print(parse(t, printed)); printed != reprinted {
+			t.Fatalf("printing is not stable, Diff: %v", testutil.Diff(printed, reprinted))
+		}
+	}
+}
+
+func TestPrinter_PrintsValues(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{`{ f(v: [1,2,3]) }`, `[1, 2, 3]`},
+		{`{ f(v: {x:1 y:2}) }`, `{x: 1, y: 2}`},
+		{`{ f(v: {point: {x: 1, y: 2}, tags: [A, B], empty: [], none: {}}) }`, `{point: {x: 1, y: 2}, tags: [A, B], empty: [], none: {}}`},
+		{`{ f(v: [[1, 2], [], [{a: "b"}]]) }`, `[[1, 2], [], [{a: "b"}]]`},
+		{`{ f(v: [null, true, 1.5, $var]) }`, `[null, true, 1.5, $var]`},
+	}
+	for _, test := range tests {
+		astDoc := parse(t, test.query)
+		field := astDoc.Definitions[0].(*ast.OperationDefinition).SelectionSet.Selections[0].(*ast.Field)
+		if results := printer.PrintValue(field.Arguments[0].Value); results != test.expected {
+			t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(test.expected, results))
+		}
+	}
+	if results := printer.PrintValue(nil); results != "" {
+		t.Fatalf("expected nil value to print as empty string, got: %q", results)
+	}
+}
+
+func TestPrinter_PrintsCompact(t *testing.T) {
+	b, err := ioutil.ReadFile("../../kitchen-sink.graphql")
+	if err != nil {
+		t.Fatalf("unable to load kitchen-sink.graphql")
+	}
+	query := `query Q($id: ID = 1, $ratio: Float = 1.5) {
+  user(id: $id, tags: [1 2 3]) @include(if: true) {
+    ...UserFields
+    ... on Admin { name }
+  }
+}
+fragment UserFields on User { id }`
+	expected := `query Q($id:ID=1$ratio:Float=1.5){user(id:$id tags:[1 2 3])@include(if:true){...UserFields...on Admin{name}}}fragment UserFields on User{id}`
+	if results := printer.PrintCompact(parse(t, query)); results != expected {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, results))
+	}
+
+	schema := `
+"""
+A user, "quoted"
+with a backslash \ in it.
+"""
+type User {
+  "The id"
+  id: ID!
+}
+`
+	for _, query := range []string{string(b), query, schema} {
+		astDoc := parse(t, query)
+		compact := printer.PrintCompact(astDoc)
+		pretty := printer.Print(astDoc).(string)
+		if strings.Contains(compact, "\n") || len(compact) >= len(pretty) {
+			t.Fatalf("expected compact output shorter than %d bytes on one line, got %d bytes:\n%v", len(pretty), len(compact), compact)
+		}
+		if reparsed := parse(t, compact); !reflect.DeepEqual(astDoc, reparsed) {
+			t.Fatalf("reparsed compact document differs from the original, printed as:\n%v", compact)
+		}
+	}
+	expected = `type User{"The id"id:ID!}`
+	if results := printer.PrintCompact(parse(t, `type User { "The id" id: ID! }`)); results != expected {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, results))
+	}
+
+	// string arguments are escaped rather than printed raw
+	for query, expected := range map[string]string{
+		"{ b(s: \"\"\"multi\nline\"\"\") }": `{b(s:"multi\nline")}`,
+		"{ b(s: \"\"\"x\n\"y\" \"\"\") }":   `{b(s:"x\n\"y\" ")}`,
+		`{ b(s: "say \"hi\"\tnow") }`:       `{b(s:"say \"hi\"\tnow")}`,
+	} {
+		astDoc := parse(t, query)
+		if results := printer.PrintCompact(astDoc); results != expected {
+			t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, results))
+		}
+		if reparsed := parse(t, expected); !reflect.DeepEqual(astDoc, reparsed) {
+			t.Fatalf("reparsed compact document differs from the original, printed as:\n%v", expected)
+		}
+	}
+}
+
+type failingWriter struct {
+	writes int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.writes == 0 {
+		return 0, errors.New("disk full")
+	}
+	w.writes--
+	return len(p), nil
+}
+
+func TestPrinter_FprintsToWriter(t *testing.T) {
+	b, err := ioutil.ReadFile("../../kitchen-sink.graphql")
+	if err != nil {
+		t.Fatalf("unable to load kitchen-sink.graphql")
+	}
+	astDoc := parse(t, string(b))
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, astDoc); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := printer.Print(astDoc); buf.String() != expected {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, buf.String()))
+	}
+
+	buf.Reset()
+	field := astDoc.Definitions[0].(*ast.OperationDefinition).SelectionSet.Selections[0].(*ast.Field)
+	if err := printer.Fprint(&buf, field); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := printer.Print(field); buf.String() != expected {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, buf.String()))
+	}
+
+	for _, writes := range []int{0, 1, 3} {
+		if err := printer.Fprint(&failingWriter{writes: writes}, astDoc); err == nil || err.Error() != "disk full" {
+			t.Fatalf("expected write error after %d writes, got: %v", writes, err)
+		}
+	}
+	if err := printer.Fprint(&failingWriter{}, field); err == nil {
+		t.Fatalf("expected write error for a single node")
+	}
+}
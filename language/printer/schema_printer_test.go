@@ -124,3 +124,102 @@ directive @include(if: Boolean!) on FIELD | FRAGMENT_SPREAD | INLINE_FRAGMENT
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, results))
 	}
 }
+
+func TestSchemaPrinter_PrintsDescriptions(t *testing.T) {
+	astDoc := parse(t, `
+"A simple scalar"
+scalar Date
+
+"""
+A user of the system.
+Called "member" in the UI.
+"""
+type User {
+  "The user id"
+  id: ID!
+  friends(
+    """
+    Limit the number
+    of friends returned.
+    """
+    first: Int
+    after: String
+  ): [User]
+}
+`)
+	expected := `"A simple scalar"
+scalar Date
+
+"""
+A user of the system.
+Called "member" in the UI.
+"""
+type User {
+  "The user id"
+  id: ID!
+  friends(
+    """
+    Limit the number
+    of friends returned.
+    """
+    first: Int
+    after: String
+  ): [User]
+}
+`
+	results := printer.Print(astDoc)
+	if !reflect.DeepEqual(expected, results) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, results))
+	}
+	if reparsed := printer.Print(parse(t, results.(string))); !reflect.DeepEqual(expected, reparsed) {
+		t.Fatalf("Unexpected result after reparsing, Diff: %v", testutil.Diff(expected, reparsed))
+	}
+
+	// control characters survive a print and parse round trip
+	astDoc = parse(t, `
+"carriage\rreturn"
+scalar A
+
+"multi\nline\rwith \"quotes\" and a bell \u0007"
+scalar B
+`)
+	expected = `"carriage\rreturn"
+scalar A
+
+"multi\nline\rwith \"quotes\" and a bell \u0007"
+scalar B
+`
+	results = printer.Print(astDoc)
+	if !reflect.DeepEqual(expected, results) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, results))
+	}
+	if reparsed := parse(t, results.(string)); !reflect.DeepEqual(astDoc, reparsed) {
+		t.Fatalf("reparsed document differs from the original, printed as:\n%v", results)
+	}
+}
+
+func TestSchemaPrinter_PrintsExtensions(t *testing.T) {
+	query := `extend type User @key(fields: "id") {
+  email: String
+}
+
+extend interface Node {
+  createdAt: String
+}
+`
+	results := printer.Print(parse(t, query))
+	if !reflect.DeepEqual(query, results) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(query, results))
+	}
+}
+
+func TestSchemaPrinter_PrintsRepeatableDirectives(t *testing.T) {
+	query := `directive @tag(name: String!) repeatable on FIELD_DEFINITION | OBJECT
+
+directive @once on FIELD
+`
+	results := printer.Print(parse(t, query))
+	if !reflect.DeepEqual(query, results) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(query, results))
+	}
+}
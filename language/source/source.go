@@ -18,3 +18,26 @@ func NewSource(s *Source) *Source {
 	}
 	return s
 }
+
+// GetLocation returns the 1-indexed line and column of the byte offset
+// position in s. Lines end at "\n", "\r\n" or a lone "\r", and a position
+// just past a trailing line terminator lies at column 1 of the next line.
+func GetLocation(s *Source, position int) (line, column int) {
+	line, lineStart := 1, 0
+	if s != nil {
+		body := s.Body
+		for i := 0; i < len(body) && i < position; i++ {
+			switch body[i] {
+			case '\r':
+				if i+1 < len(body) && body[i+1] == '\n' {
+					i++
+				}
+				fallthrough
+			case '\n':
+				line++
+				lineStart = i + 1
+			}
+		}
+	}
+	return line, position + 1 - lineStart
+}
@@ -0,0 +1,34 @@
+package source
+
+import "testing"
+
+func TestGetLocation(t *testing.T) {
+	tests := []struct {
+		body         string
+		position     int
+		line, column int
+	}{
+		{"{ field }", 0, 1, 1},
+		{"{ field }", 2, 1, 3},
+		{"{\n  field }", 4, 2, 3},
+		{"{\r\n  field }", 5, 2, 3},
+		{"{\r  field }", 4, 2, 3},
+		{"{\n\n\n  field }", 6, 4, 3},
+		{"{ field }\n", 10, 2, 1},
+		{"{ field }\r\n", 11, 2, 1},
+		{"{ field }", 9, 1, 10},
+	}
+	for _, test := range tests {
+		line, column := GetLocation(&Source{Body: []byte(test.body)}, test.position)
+		if line != test.line || column != test.column {
+			t.Fatalf("unexpected location of %d in %q, expected %d:%d, got %d:%d",
+				test.position, test.body, test.line, test.column, line, column)
+		}
+	}
+}
+
+func TestGetLocation_WithoutSource(t *testing.T) {
+	if line, column := GetLocation(nil, 3); line != 1 || column != 4 {
+		t.Fatalf("unexpected location, expected 1:4, got %d:%d", line, column)
+	}
+}
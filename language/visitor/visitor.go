@@ -66,6 +66,7 @@ var QueryDocumentKeys = KeyMap{
 	"FloatValue":   []string{},
 	"StringValue":  []string{},
 	"BooleanValue": []string{},
+	"NullValue":    []string{},
 	"EnumValue":    []string{},
 	"ListValue":    []string{"Values"},
 	"ObjectValue":  []string{"Fields"},
@@ -136,7 +137,8 @@ var QueryDocumentKeys = KeyMap{
 		"Fields",
 	},
 
-	"TypeExtensionDefinition": []string{"Definition"},
+	"TypeExtensionDefinition":      []string{"Definition"},
+	"InterfaceExtensionDefinition": []string{"Definition"},
 
 	"DirectiveDefinition": []string{"Name", "Arguments", "Locations"},
 }
@@ -502,6 +504,12 @@ func updateNodeField(src interface{}, targetName string, target interface{}) int
 		return src
 	}
 
+	// update a copy so that the visited AST is left untouched
+	copied := reflect.New(srcVal.Type()).Elem()
+	copied.Set(srcVal)
+	srcVal = copied
+	srcFieldValue = srcVal.FieldByName(targetName)
+
 	if srcFieldValue.CanSet() {
 		if srcFieldValue.Kind() == reflect.Slice {
 			items := reflect.MakeSlice(srcFieldValue.Type(), targetVal.Len(), targetVal.Len())
@@ -180,6 +180,61 @@ func TestVisitor_AllowsForEditingOnEnter(t *testing.T) {
 	}
 
 }
+func TestVisitor_AllowsRemovingDirectives(t *testing.T) {
+	query := `query Q @skip(if: false) {
+  a @skip(if: true)
+  b @include(if: true) @skip(if: $x)
+  ... @skip(if: true) {
+    c
+  }
+  ...F @skip(if: true) @defer
+}`
+	astDoc, err := parser.Parse(parser.ParseParams{
+		Source:  query,
+		Options: parser.ParseOptions{NoSource: true},
+	})
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	v := &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.Directive: {
+				Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
+					if node, ok := p.Node.(*ast.Directive); ok && node.Name.Value == "skip" {
+						return visitor.ActionUpdate, nil
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+		},
+	}
+	editedAST, ok := visitor.Visit(astDoc, v, nil).(*ast.Document)
+	if !ok {
+		t.Fatalf("expected an edited document")
+	}
+
+	expected := `query Q {
+  a
+  b @include(if: true)
+  ... {
+    c
+  }
+  ...F @defer
+}
+`
+	if results := printer.Print(editedAST); !reflect.DeepEqual(expected, results) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, results))
+	}
+	if original := printer.Print(astDoc); !reflect.DeepEqual(original, printer.Print(parse(t, query))) {
+		t.Fatalf("expected the original document to be left untouched, got: %v", original)
+	}
+	original := astDoc.Definitions[0].(*ast.OperationDefinition).SelectionSet.Selections[1].(*ast.Field)
+	edited := editedAST.Definitions[0].(*ast.OperationDefinition).SelectionSet.Selections[1].(*ast.Field)
+	if !reflect.DeepEqual(edited.Loc, original.Loc) || !reflect.DeepEqual(edited.Directives[0].Loc, original.Directives[0].Loc) {
+		t.Fatalf("expected untouched nodes to keep their locations, got: %v", edited.Loc)
+	}
+}
+
 func TestVisitor_AllowsForEditingOnLeave(t *testing.T) {
 
 	query := `{ a, b, c { a, b, c } }`
@@ -477,6 +532,53 @@ func TestVisitor_AllowsANamedFunctionsVisitorAPI(t *testing.T) {
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expectedVisited, visited))
 	}
 }
+func TestVisitor_CountsFieldsAndSkipsOrBreaksOnFields(t *testing.T) {
+	query := `
+query Q($id: ID) {
+  user(id: $id) @include(if: true) {
+    name
+    friends(first: 10) { name avatar { url } }
+    ... on Admin { permissions }
+  }
+}
+fragment F on User { id }
+`
+	astDoc := parse(t, query)
+	tests := []struct {
+		action   string
+		on       string
+		expected []string
+	}{
+		{visitor.ActionNoChange, "", []string{"user", "name", "friends", "name", "avatar", "url", "permissions", "id"}},
+		{visitor.ActionSkip, "friends", []string{"user", "name", "friends", "permissions", "id"}},
+		{visitor.ActionBreak, "friends", []string{"user", "name", "friends"}},
+	}
+	for _, test := range tests {
+		visited := []string{}
+		v := &visitor.VisitorOptions{
+			KindFuncMap: map[string]visitor.NamedVisitFuncs{
+				kinds.Field: {
+					Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
+						field, ok := p.Node.(*ast.Field)
+						if !ok {
+							return visitor.ActionNoChange, nil
+						}
+						visited = append(visited, field.Name.Value)
+						if field.Name.Value == test.on {
+							return test.action, nil
+						}
+						return visitor.ActionNoChange, nil
+					},
+				},
+			},
+		}
+		_ = visitor.Visit(astDoc, v, nil)
+		if !reflect.DeepEqual(visited, test.expected) {
+			t.Fatalf("Unexpected result for %q, Diff: %v", test.action, testutil.Diff(test.expected, visited))
+		}
+	}
+}
+
 func TestVisitor_VisitsKitchenSink(t *testing.T) {
 	b, err := ioutil.ReadFile("../../kitchen-sink.graphql")
 	if err != nil {
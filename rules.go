@@ -1725,6 +1725,11 @@ func VariablesInAllowedPositionRule(context *ValidationContext) *ValidationRuleI
 // Note that this only validates literal values, variables are assumed to
 // provide values of the correct type.
 func isValidLiteralValue(ttype Input, valueAST ast.Value) (bool, []string) {
+	// An explicit null literal is valid exactly where an omitted value is, i.e.
+	// anywhere but in a non-null position.
+	if _, ok := valueAST.(*ast.NullValue); ok {
+		valueAST = nil
+	}
 	if _, ok := ttype.(*NonNull); !ok {
 		if valueAST == nil {
 			return true, nil
@@ -81,6 +81,31 @@ func TestValidate_ArgValuesOfCorrectType_ValidValue_GoodEnumValue(t *testing.T)
     `)
 }
 
+func TestValidate_ArgValuesOfCorrectType_ValidValue_NullIntoNullableArg(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.ArgumentsOfCorrectTypeRule, `
+        {
+          complicatedArgs {
+            intArgField(intArg: null)
+            multipleOpts(opt1: null)
+          }
+        }
+    `)
+}
+func TestValidate_ArgValuesOfCorrectType_InvalidValue_NullIntoNonNullArg(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.ArgumentsOfCorrectTypeRule, `
+        {
+          complicatedArgs {
+            nonNullIntArgField(nonNullIntArg: null)
+          }
+        }
+        `,
+		[]gqlerrors.FormattedError{
+			testutil.RuleError(
+				"Argument \"nonNullIntArg\" has invalid value null.\nExpected \"Int!\", found null.",
+				4, 47,
+			),
+		})
+}
 func TestValidate_ArgValuesOfCorrectType_InvalidStringValues_IntIntoString(t *testing.T) {
 	testutil.ExpectFailsRule(t, graphql.ArgumentsOfCorrectTypeRule, `
         {
@@ -81,3 +81,10 @@ func TestValidate_AnonymousOperationMustBeAlone_AnonOperationWithASubscription(t
 		testutil.RuleError(`This anonymous operation must be the only defined operation.`, 2, 7),
 	})
 }
+
+func TestValidate_AnonymousOperationMustBeAlone_ShorthandQueryWithNamedQuery(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.LoneAnonymousOperationRule, `{ a } query B { b }`,
+		[]gqlerrors.FormattedError{
+			testutil.RuleError(`This anonymous operation must be the only defined operation.`, 1, 1),
+		})
+}
@@ -57,6 +57,11 @@ func getArgumentValues(
 		if tmpValue, ok := argASTMap[argDef.PrivateName]; ok {
 			value = tmpValue.Value
 		}
+		// An explicit null overrides the default value.
+		if _, ok := value.(*ast.NullValue); ok {
+			results[argDef.PrivateName] = nil
+			continue
+		}
 		if tmp = valueFromAST(value, argDef.Type, variableValues); isNullish(tmp) {
 			tmp = argDef.DefaultValue
 		}
@@ -352,6 +357,9 @@ func valueFromAST(valueAST ast.Value, ttype Input, variables map[string]interfac
 	if valueAST == nil {
 		return nil
 	}
+	if _, ok := valueAST.(*ast.NullValue); ok {
+		return nil
+	}
 	// precedence: value > type
 	if valueAST, ok := valueAST.(*ast.Variable); ok {
 		if valueAST.Name == nil || variables == nil {
@@ -394,6 +402,10 @@ func valueFromAST(valueAST ast.Value, ttype Input, variables map[string]interfac
 		for name, field := range ttype.Fields() {
 			var value interface{}
 			if of, ok = fieldASTs[name]; ok {
+				if _, isNull := of.Value.(*ast.NullValue); isNull {
+					obj[name] = nil
+					continue
+				}
 				value = valueFromAST(of.Value, field.Type, variables)
 			} else {
 				value = field.DefaultValue